@@ -23,11 +23,16 @@ func NewAnalyzer(tokens []lexer.Token) *Analyzer {
 
 func (a *Analyzer) Analyze() {
 	a.checkBracketMatching()
+	a.checkMisspelledIdentifiers()
 
 	for i := 0; i < len(a.tokens); i++ {
 		tok := a.tokens[i]
 
-		if tok.Type == lexer.ILLEGAL {
+		if tok.Type == lexer.ILLEGAL && looksLikeNumber(tok.Literal) {
+			a.errors = append(a.errors,
+				fmt.Sprintf("Line %d, Column %d: %s",
+					tok.Line, tok.Column, numberError(tok.Literal)))
+		} else if tok.Type == lexer.ILLEGAL {
 			a.errors = append(a.errors,
 				fmt.Sprintf("Line %d, Column %d: Illegal character: %s",
 					tok.Line, tok.Column, tok.Literal))
@@ -63,8 +68,8 @@ func (a *Analyzer) checkSyntaxErrors(i int) {
 
 		if next.Type == lexer.DOT && (afterNext.Type == lexer.INT || afterNext.Type == lexer.FLOAT) {
 			a.errors = append(a.errors,
-				fmt.Sprintf("Line %d, Column %d: Invalid number literal: malformed numeric sequence '%s.%s'",
-					tok.Line, tok.Column, tok.Literal, afterNext.Literal))
+				fmt.Sprintf("Line %d, Column %d: %s",
+					tok.Line, tok.Column, numberError(tok.Literal+"."+afterNext.Literal)))
 		}
 	}
 
@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"Lab1/lexer"
+	"fmt"
+	"path"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+// maxSuggestionDistance bounds how far a misspelled name may be from the
+// declared symbol suggested for it.
+const maxSuggestionDistance = 2
+
+// predeclared names are never reported; the lexer also returns the Go
+// keywords it does not know (break, range, ...) as identifiers.
+var predeclared = map[string]bool{
+	"bool": true, "byte": true, "complex64": true, "complex128": true, "error": true,
+	"float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true,
+	"uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"any": true, "true": true, "false": true, "iota": true, "nil": true,
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true,
+	"copy": true, "delete": true, "imag": true, "len": true, "make": true,
+	"max": true, "min": true, "new": true, "panic": true, "print": true,
+	"println": true, "real": true, "recover": true,
+	"break": true, "case": true, "chan": true, "continue": true, "default": true,
+	"defer": true, "fallthrough": true, "go": true, "goto": true, "interface": true,
+	"map": true, "range": true, "select": true, "switch": true,
+}
+
+// collectDeclarations returns the names declared anywhere in the file:
+// var/const/type names, the left side of :=, function and method names,
+// parameters, receivers, struct fields and imported packages. Scopes are
+// ignored, which can only hide errors, never report false ones.
+func (a *Analyzer) collectDeclarations() map[string]bool {
+	declared := make(map[string]bool)
+	tokens := a.tokens
+	at := func(i int) lexer.TokenType {
+		if i < 0 || i >= len(tokens) {
+			return lexer.EOF
+		}
+		return tokens[i].Type
+	}
+
+	// declareList records the identifier list a, b, c starting at tokens[j].
+	declareList := func(j int) {
+		for ; at(j) == lexer.IDENTIFIER; j += 2 {
+			declared[tokens[j].Literal] = true
+			if at(j+1) != lexer.COMMA {
+				break
+			}
+		}
+	}
+
+	for i, tok := range tokens {
+		switch tok.Type {
+		case lexer.VAR, lexer.CONST, lexer.TYPE:
+			if at(i+1) != lexer.LPAREN {
+				declareList(i + 1)
+				break
+			}
+			// A group: every spec starts a line (or follows a ';') at the
+			// group's own nesting level.
+			depth := 0
+			for j := i + 1; j < len(tokens); j++ {
+				switch at(j) {
+				case lexer.LPAREN, lexer.LBRACE:
+					depth++
+				case lexer.RPAREN, lexer.RBRACE:
+					depth--
+				}
+				if depth == 0 {
+					break
+				}
+				if depth == 1 && (at(j) == lexer.LPAREN || at(j) == lexer.SEMICOLON || tokens[j].Line > tokens[j-1].Line) {
+					if at(j) == lexer.IDENTIFIER {
+						declareList(j)
+					} else {
+						declareList(j + 1)
+					}
+				}
+			}
+		case lexer.SHORT_ASSIGN:
+			for j := i - 1; at(j) == lexer.IDENTIFIER; j -= 2 {
+				declared[tokens[j].Literal] = true
+				if at(j-1) != lexer.COMMA {
+					break
+				}
+			}
+		case lexer.FUNC:
+			signatureDepth := 0 // parentheses open inside the signature
+			for j := i + 1; j < len(tokens) && at(j) != lexer.LBRACE; j++ {
+				switch at(j) {
+				case lexer.LPAREN:
+					signatureDepth++
+				case lexer.RPAREN:
+					signatureDepth--
+				case lexer.IDENTIFIER:
+					next := at(j + 1)
+					if signatureDepth == 0 && next == lexer.LPAREN ||
+						signatureDepth > 0 && (next == lexer.COMMA || next == lexer.IDENTIFIER || next == lexer.ASTERISK) {
+						declared[tokens[j].Literal] = true
+					}
+				}
+			}
+		case lexer.STRUCT:
+			if at(i+1) == lexer.LBRACE {
+				structDepth := 1
+				for j := i + 2; j < len(tokens) && structDepth > 0; j++ {
+					switch at(j) {
+					case lexer.LBRACE:
+						structDepth++
+					case lexer.RBRACE:
+						structDepth--
+					case lexer.IDENTIFIER:
+						if next := at(j + 1); next == lexer.IDENTIFIER || next == lexer.ASTERISK || next == lexer.COMMA {
+							declared[tokens[j].Literal] = true
+						}
+					}
+				}
+			}
+		case lexer.IMPORT:
+			for j := i + 1; at(j) == lexer.STRING || at(j) == lexer.LPAREN; j++ {
+				if at(j) == lexer.STRING {
+					declared[path.Base(tokens[j].Literal)] = true
+				}
+			}
+		}
+	}
+
+	return declared
+}
+
+// checkMisspelledIdentifiers reports identifiers that are not declared but
+// are within maxSuggestionDistance edits of a declared one. The declared
+// names are compiled into a dictionary automaton and each unknown name is
+// matched against it with growing edit-distance tolerance, so the closest
+// declared symbol is suggested.
+func (a *Analyzer) checkMisspelledIdentifiers() {
+	declared := a.collectDeclarations()
+	names := []string{}
+	for name := range declared {
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	dictionary, err := automaton.MinimalAcyclicDFA(names)
+	if err != nil {
+		return
+	}
+
+	reported := make(map[string]bool)
+	for i, tok := range a.tokens {
+		if tok.Type != lexer.IDENTIFIER || declared[tok.Literal] || predeclared[tok.Literal] {
+			continue
+		}
+		if i > 0 && a.tokens[i-1].Type == lexer.DOT {
+			continue // field, method or package member
+		}
+		if i+1 < len(a.tokens) && a.tokens[i+1].Type == lexer.COLON {
+			continue // label or composite literal key
+		}
+
+		suggestion, found := closestSymbol(dictionary, tok.Literal)
+		if !found || reported[tok.Literal] {
+			continue
+		}
+		reported[tok.Literal] = true
+		a.errors = append(a.errors,
+			fmt.Sprintf("Line %d, Column %d: Undeclared identifier '%s' (did you mean '%s'?)",
+				tok.Line, tok.Column, tok.Literal, suggestion))
+	}
+}
+
+// closestSymbol allows at most maxSuggestionDistance edits, and fewer for
+// short names, where a couple of edits reach almost any other name.
+func closestSymbol(dictionary *automaton.FiniteAutomaton, name string) (string, bool) {
+	limit := min(maxSuggestionDistance, (len(name)-1)/2)
+	for k := 1; k <= limit; k++ {
+		if match, found := automaton.ApproxMatch(dictionary, name, k); found {
+			return match, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,56 @@
+package analyzer
+
+import (
+	"github.com/bujor/compilers/shared/automaton"
+	"github.com/bujor/compilers/shared/automaton/definitions"
+)
+
+// numberAutomata are only used to suggest corrections for malformed number
+// literals. Float comes first so that it wins ties: "1._5" becomes "1.5"
+// rather than "10_5".
+var numberAutomata = loadNumberAutomata()
+
+func loadNumberAutomata() []*automaton.FiniteAutomaton {
+	automata := []*automaton.FiniteAutomaton{}
+	for _, name := range []string{"float", "integer"} {
+		if fa, err := definitions.Load(name); err == nil {
+			automata = append(automata, fa)
+		}
+	}
+	return automata
+}
+
+// suggestNumber returns the valid number literal at minimum edit distance
+// from literal.
+func suggestNumber(literal string) (string, bool) {
+	var best *automaton.Repair
+	for _, fa := range numberAutomata {
+		if repair, found := fa.Repair(literal); found && (best == nil || repair.Distance < best.Distance) {
+			best = repair
+		}
+	}
+	if best == nil {
+		return "", false
+	}
+	return best.Output, true
+}
+
+func looksLikeNumber(literal string) bool {
+	if literal == "" {
+		return false
+	}
+	if literal[0] >= '0' && literal[0] <= '9' {
+		return true
+	}
+	return len(literal) > 1 && literal[0] == '.' && literal[1] >= '0' && literal[1] <= '9'
+}
+
+// numberError describes a malformed number literal, with a suggestion when
+// one is found.
+func numberError(literal string) string {
+	message := "Invalid number literal: '" + literal + "'"
+	if suggestion, found := suggestNumber(literal); found {
+		message += " (did you mean '" + suggestion + "'?)"
+	}
+	return message
+}
@@ -0,0 +1,222 @@
+// Code generated by fa2go from float.json; DO NOT EDIT.
+
+package lexer
+
+// States of the "Literal în virgulă mobilă Go" matcher:
+//	0: q0
+//	1: q2
+//	2: q1
+//	3: q10
+//	4: q3
+//	5: q21
+//	6: q11
+//	7: q6
+//	8: q4
+//	9: q5
+//	10: q14
+//	11: q12
+//	12: q7
+//	13: q8
+//	14: q15
+//	15: q17
+//	16: q13
+//	17: q9
+//	18: q16
+//	19: q18
+//	20: q19
+//	21: q20
+
+// stepFloat returns the next state, or -1 when there is no transition.
+func stepFloat(state int, r rune) int {
+	switch state {
+	case 0:
+		switch r {
+		case '.':
+			return 1
+		case '0':
+			return 2
+		case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 3
+		}
+	case 1:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 4
+		}
+	case 2:
+		switch r {
+		case '.':
+			return 5
+		case 'X', 'x':
+			return 6
+		}
+	case 3:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 3
+		case '.':
+			return 5
+		case 'E', 'e':
+			return 7
+		case '_':
+			return 8
+		}
+	case 4:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 4
+		case 'E', 'e':
+			return 7
+		case '_':
+			return 9
+		}
+	case 5:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 4
+		case 'E', 'e':
+			return 7
+		}
+	case 6:
+		switch r {
+		case '_':
+			return 6
+		case '.':
+			return 10
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 11
+		}
+	case 7:
+		switch r {
+		case '+', '-':
+			return 12
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 13
+		}
+	case 8:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 3
+		}
+	case 9:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 4
+		}
+	case 10:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 14
+		}
+	case 11:
+		switch r {
+		case '.':
+			return 10
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 11
+		case 'P', 'p':
+			return 15
+		case '_':
+			return 16
+		}
+	case 12:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 13
+		}
+	case 13:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 13
+		case '_':
+			return 17
+		}
+	case 14:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 14
+		case 'P', 'p':
+			return 15
+		case '_':
+			return 18
+		}
+	case 15:
+		switch r {
+		case '+', '-':
+			return 19
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 20
+		}
+	case 16:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 11
+		}
+	case 17:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 13
+		}
+	case 18:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 14
+		}
+	case 19:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 20
+		}
+	case 20:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 20
+		case '_':
+			return 21
+		}
+	case 21:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 20
+		}
+	}
+	return -1
+}
+
+func acceptingFloat(state int) bool {
+	switch state {
+	case 4, 5, 13, 20:
+		return true
+	}
+	return false
+}
+
+// MatchFloat reports whether the whole of s is accepted.
+func MatchFloat(s string) bool {
+	state := 0
+	for _, r := range s {
+		if state = stepFloat(state, r); state < 0 {
+			return false
+		}
+	}
+	return acceptingFloat(state)
+}
+
+// LongestPrefixFloat returns the length in bytes of the longest accepted prefix of s,
+// or -1 when no prefix is accepted.
+func LongestPrefixFloat(s string) int {
+	longest := -1
+	state := 0
+	if acceptingFloat(state) {
+		longest = 0
+	}
+	for i, r := range s {
+		if state = stepFloat(state, r); state < 0 {
+			break
+		}
+		if acceptingFloat(state) {
+			longest = i + len(string(r))
+		}
+	}
+	return longest
+}
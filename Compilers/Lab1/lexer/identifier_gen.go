@@ -0,0 +1,62 @@
+// Code generated by fa2go from identifier.json; DO NOT EDIT.
+
+package lexer
+
+// States of the "Identificator Go" matcher:
+//	0: q0
+//	1: q1
+
+// stepIdentifier returns the next state, or -1 when there is no transition.
+func stepIdentifier(state int, r rune) int {
+	switch state {
+	case 0:
+		switch r {
+		case 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z', '_', 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+			return 1
+		}
+	case 1:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'G', 'H', 'I', 'J', 'K', 'L', 'M', 'N', 'O', 'P', 'Q', 'R', 'S', 'T', 'U', 'V', 'W', 'X', 'Y', 'Z', '_', 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z':
+			return 1
+		}
+	}
+	return -1
+}
+
+func acceptingIdentifier(state int) bool {
+	switch state {
+	case 1:
+		return true
+	}
+	return false
+}
+
+// MatchIdentifier reports whether the whole of s is accepted.
+func MatchIdentifier(s string) bool {
+	state := 0
+	for _, r := range s {
+		if state = stepIdentifier(state, r); state < 0 {
+			return false
+		}
+	}
+	return acceptingIdentifier(state)
+}
+
+// LongestPrefixIdentifier returns the length in bytes of the longest accepted prefix of s,
+// or -1 when no prefix is accepted.
+func LongestPrefixIdentifier(s string) int {
+	longest := -1
+	state := 0
+	if acceptingIdentifier(state) {
+		longest = 0
+	}
+	for i, r := range s {
+		if state = stepIdentifier(state, r); state < 0 {
+			break
+		}
+		if acceptingIdentifier(state) {
+			longest = i + len(string(r))
+		}
+	}
+	return longest
+}
@@ -0,0 +1,444 @@
+// Code generated by fa2go from identifier_unicode.json; DO NOT EDIT.
+
+package lexer
+
+// States of the "Identificator Go (Unicode)" matcher:
+//	0: q0
+//	1: q1
+
+// inRangesUnicodeIdentifier reports whether r lies in one of the sorted, disjoint ranges.
+func inRangesUnicodeIdentifier(r rune, ranges [][2]rune) bool {
+	lo, hi := 0, len(ranges)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch {
+		case r < ranges[mid][0]:
+			hi = mid
+		case r > ranges[mid][1]:
+			lo = mid + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// guardUnicodeIdentifier0 is [\p{L}_].
+var guardUnicodeIdentifier0 = [][2]rune{
+	{'A', 'Z'}, {'_', '_'}, {'a', 'z'}, {'ª', 'ª'},
+	{'µ', 'µ'}, {'º', 'º'}, {'À', 'Ö'}, {'Ø', 'ö'},
+	{'ø', 'ˁ'}, {'ˆ', 'ˑ'}, {'ˠ', 'ˤ'}, {'ˬ', 'ˬ'},
+	{'ˮ', 'ˮ'}, {'Ͱ', 'ʹ'}, {'Ͷ', 'ͷ'}, {'ͺ', 'ͽ'},
+	{'Ϳ', 'Ϳ'}, {'Ά', 'Ά'}, {'Έ', 'Ί'}, {'Ό', 'Ό'},
+	{'Ύ', 'Ρ'}, {'Σ', 'ϵ'}, {'Ϸ', 'ҁ'}, {'Ҋ', 'ԯ'},
+	{'Ա', 'Ֆ'}, {'ՙ', 'ՙ'}, {'ՠ', 'ֈ'}, {'א', 'ת'},
+	{'ׯ', 'ײ'}, {'ؠ', 'ي'}, {'ٮ', 'ٯ'}, {'ٱ', 'ۓ'},
+	{'ە', 'ە'}, {'ۥ', 'ۦ'}, {'ۮ', 'ۯ'}, {'ۺ', 'ۼ'},
+	{'ۿ', 'ۿ'}, {'ܐ', 'ܐ'}, {'ܒ', 'ܯ'}, {'ݍ', 'ޥ'},
+	{'ޱ', 'ޱ'}, {'ߊ', 'ߪ'}, {'ߴ', 'ߵ'}, {'ߺ', 'ߺ'},
+	{'ࠀ', 'ࠕ'}, {'ࠚ', 'ࠚ'}, {'ࠤ', 'ࠤ'}, {'ࠨ', 'ࠨ'},
+	{'ࡀ', 'ࡘ'}, {'ࡠ', 'ࡪ'}, {'ࡰ', 'ࢇ'}, {'ࢉ', '࢏'},
+	{'ࢠ', 'ࣉ'}, {'ऄ', 'ह'}, {'ऽ', 'ऽ'}, {'ॐ', 'ॐ'},
+	{'क़', 'ॡ'}, {'ॱ', 'ঀ'}, {'অ', 'ঌ'}, {'এ', 'ঐ'},
+	{'ও', 'ন'}, {'প', 'র'}, {'ল', 'ল'}, {'শ', 'হ'},
+	{'ঽ', 'ঽ'}, {'ৎ', 'ৎ'}, {'ড়', 'ঢ়'}, {'য়', 'ৡ'},
+	{'ৰ', 'ৱ'}, {'ৼ', 'ৼ'}, {'ਅ', 'ਊ'}, {'ਏ', 'ਐ'},
+	{'ਓ', 'ਨ'}, {'ਪ', 'ਰ'}, {'ਲ', 'ਲ਼'}, {'ਵ', 'ਸ਼'},
+	{'ਸ', 'ਹ'}, {'ਖ਼', 'ੜ'}, {'ਫ਼', 'ਫ਼'}, {'ੲ', 'ੴ'},
+	{'અ', 'ઍ'}, {'એ', 'ઑ'}, {'ઓ', 'ન'}, {'પ', 'ર'},
+	{'લ', 'ળ'}, {'વ', 'હ'}, {'ઽ', 'ઽ'}, {'ૐ', 'ૐ'},
+	{'ૠ', 'ૡ'}, {'ૹ', 'ૹ'}, {'ଅ', 'ଌ'}, {'ଏ', 'ଐ'},
+	{'ଓ', 'ନ'}, {'ପ', 'ର'}, {'ଲ', 'ଳ'}, {'ଵ', 'ହ'},
+	{'ଽ', 'ଽ'}, {'ଡ଼', 'ଢ଼'}, {'ୟ', 'ୡ'}, {'ୱ', 'ୱ'},
+	{'ஃ', 'ஃ'}, {'அ', 'ஊ'}, {'எ', 'ஐ'}, {'ஒ', 'க'},
+	{'ங', 'ச'}, {'ஜ', 'ஜ'}, {'ஞ', 'ட'}, {'ண', 'த'},
+	{'ந', 'ப'}, {'ம', 'ஹ'}, {'ௐ', 'ௐ'}, {'అ', 'ఌ'},
+	{'ఎ', 'ఐ'}, {'ఒ', 'న'}, {'ప', 'హ'}, {'ఽ', 'ఽ'},
+	{'ౘ', 'ౚ'}, {'౜', 'ౝ'}, {'ౠ', 'ౡ'}, {'ಀ', 'ಀ'},
+	{'ಅ', 'ಌ'}, {'ಎ', 'ಐ'}, {'ಒ', 'ನ'}, {'ಪ', 'ಳ'},
+	{'ವ', 'ಹ'}, {'ಽ', 'ಽ'}, {'೜', 'ೞ'}, {'ೠ', 'ೡ'},
+	{'ೱ', 'ೲ'}, {'ഄ', 'ഌ'}, {'എ', 'ഐ'}, {'ഒ', 'ഺ'},
+	{'ഽ', 'ഽ'}, {'ൎ', 'ൎ'}, {'ൔ', 'ൖ'}, {'ൟ', 'ൡ'},
+	{'ൺ', 'ൿ'}, {'අ', 'ඖ'}, {'ක', 'න'}, {'ඳ', 'ර'},
+	{'ල', 'ල'}, {'ව', 'ෆ'}, {'ก', 'ะ'}, {'า', 'ำ'},
+	{'เ', 'ๆ'}, {'ກ', 'ຂ'}, {'ຄ', 'ຄ'}, {'ຆ', 'ຊ'},
+	{'ຌ', 'ຣ'}, {'ລ', 'ລ'}, {'ວ', 'ະ'}, {'າ', 'ຳ'},
+	{'ຽ', 'ຽ'}, {'ເ', 'ໄ'}, {'ໆ', 'ໆ'}, {'ໜ', 'ໟ'},
+	{'ༀ', 'ༀ'}, {'ཀ', 'ཇ'}, {'ཉ', 'ཬ'}, {'ྈ', 'ྌ'},
+	{'က', 'ဪ'}, {'ဿ', 'ဿ'}, {'ၐ', 'ၕ'}, {'ၚ', 'ၝ'},
+	{'ၡ', 'ၡ'}, {'ၥ', 'ၦ'}, {'ၮ', 'ၰ'}, {'ၵ', 'ႁ'},
+	{'ႎ', 'ႎ'}, {'Ⴀ', 'Ⴥ'}, {'Ⴧ', 'Ⴧ'}, {'Ⴭ', 'Ⴭ'},
+	{'ა', 'ჺ'}, {'ჼ', 'ቈ'}, {'ቊ', 'ቍ'}, {'ቐ', 'ቖ'},
+	{'ቘ', 'ቘ'}, {'ቚ', 'ቝ'}, {'በ', 'ኈ'}, {'ኊ', 'ኍ'},
+	{'ነ', 'ኰ'}, {'ኲ', 'ኵ'}, {'ኸ', 'ኾ'}, {'ዀ', 'ዀ'},
+	{'ዂ', 'ዅ'}, {'ወ', 'ዖ'}, {'ዘ', 'ጐ'}, {'ጒ', 'ጕ'},
+	{'ጘ', 'ፚ'}, {'ᎀ', 'ᎏ'}, {'Ꭰ', 'Ᏽ'}, {'ᏸ', 'ᏽ'},
+	{'ᐁ', 'ᙬ'}, {'ᙯ', 'ᙿ'}, {'ᚁ', 'ᚚ'}, {'ᚠ', 'ᛪ'},
+	{'ᛱ', 'ᛸ'}, {'ᜀ', 'ᜑ'}, {'ᜟ', 'ᜱ'}, {'ᝀ', 'ᝑ'},
+	{'ᝠ', 'ᝬ'}, {'ᝮ', 'ᝰ'}, {'ក', 'ឳ'}, {'ៗ', 'ៗ'},
+	{'ៜ', 'ៜ'}, {'ᠠ', 'ᡸ'}, {'ᢀ', 'ᢄ'}, {'ᢇ', 'ᢨ'},
+	{'ᢪ', 'ᢪ'}, {'ᢰ', 'ᣵ'}, {'ᤀ', 'ᤞ'}, {'ᥐ', 'ᥭ'},
+	{'ᥰ', 'ᥴ'}, {'ᦀ', 'ᦫ'}, {'ᦰ', 'ᧉ'}, {'ᨀ', 'ᨖ'},
+	{'ᨠ', 'ᩔ'}, {'ᪧ', 'ᪧ'}, {'ᬅ', 'ᬳ'}, {'ᭅ', 'ᭌ'},
+	{'ᮃ', 'ᮠ'}, {'ᮮ', 'ᮯ'}, {'ᮺ', 'ᯥ'}, {'ᰀ', 'ᰣ'},
+	{'ᱍ', 'ᱏ'}, {'ᱚ', 'ᱽ'}, {'ᲀ', 'ᲊ'}, {'Ა', 'Ჺ'},
+	{'Ჽ', 'Ჿ'}, {'ᳩ', 'ᳬ'}, {'ᳮ', 'ᳳ'}, {'ᳵ', 'ᳶ'},
+	{'ᳺ', 'ᳺ'}, {'ᴀ', 'ᶿ'}, {'Ḁ', 'ἕ'}, {'Ἐ', 'Ἕ'},
+	{'ἠ', 'ὅ'}, {'Ὀ', 'Ὅ'}, {'ὐ', 'ὗ'}, {'Ὑ', 'Ὑ'},
+	{'Ὓ', 'Ὓ'}, {'Ὕ', 'Ὕ'}, {'Ὗ', 'ώ'}, {'ᾀ', 'ᾴ'},
+	{'ᾶ', 'ᾼ'}, {'ι', 'ι'}, {'ῂ', 'ῄ'}, {'ῆ', 'ῌ'},
+	{'ῐ', 'ΐ'}, {'ῖ', 'Ί'}, {'ῠ', 'Ῥ'}, {'ῲ', 'ῴ'},
+	{'ῶ', 'ῼ'}, {'ⁱ', 'ⁱ'}, {'ⁿ', 'ⁿ'}, {'ₐ', 'ₜ'},
+	{'ℂ', 'ℂ'}, {'ℇ', 'ℇ'}, {'ℊ', 'ℓ'}, {'ℕ', 'ℕ'},
+	{'ℙ', 'ℝ'}, {'ℤ', 'ℤ'}, {'Ω', 'Ω'}, {'ℨ', 'ℨ'},
+	{'K', 'ℭ'}, {'ℯ', 'ℹ'}, {'ℼ', 'ℿ'}, {'ⅅ', 'ⅉ'},
+	{'ⅎ', 'ⅎ'}, {'Ↄ', 'ↄ'}, {'Ⰰ', 'ⳤ'}, {'Ⳬ', 'ⳮ'},
+	{'Ⳳ', 'ⳳ'}, {'ⴀ', 'ⴥ'}, {'ⴧ', 'ⴧ'}, {'ⴭ', 'ⴭ'},
+	{'ⴰ', 'ⵧ'}, {'ⵯ', 'ⵯ'}, {'ⶀ', 'ⶖ'}, {'ⶠ', 'ⶦ'},
+	{'ⶨ', 'ⶮ'}, {'ⶰ', 'ⶶ'}, {'ⶸ', 'ⶾ'}, {'ⷀ', 'ⷆ'},
+	{'ⷈ', 'ⷎ'}, {'ⷐ', 'ⷖ'}, {'ⷘ', 'ⷞ'}, {'ⸯ', 'ⸯ'},
+	{'々', '〆'}, {'〱', '〵'}, {'〻', '〼'}, {'ぁ', 'ゖ'},
+	{'ゝ', 'ゟ'}, {'ァ', 'ヺ'}, {'ー', 'ヿ'}, {'ㄅ', 'ㄯ'},
+	{'ㄱ', 'ㆎ'}, {'ㆠ', 'ㆿ'}, {'ㇰ', 'ㇿ'}, {'㐀', '䶿'},
+	{'一', 'ꒌ'}, {'ꓐ', 'ꓽ'}, {'ꔀ', 'ꘌ'}, {'ꘐ', 'ꘟ'},
+	{'ꘪ', 'ꘫ'}, {'Ꙁ', 'ꙮ'}, {'ꙿ', 'ꚝ'}, {'ꚠ', 'ꛥ'},
+	{'ꜗ', 'ꜟ'}, {'Ꜣ', 'ꞈ'}, {'Ꞌ', 'Ƛ'}, {'꟱', 'ꠁ'},
+	{'ꠃ', 'ꠅ'}, {'ꠇ', 'ꠊ'}, {'ꠌ', 'ꠢ'}, {'ꡀ', 'ꡳ'},
+	{'ꢂ', 'ꢳ'}, {'ꣲ', 'ꣷ'}, {'ꣻ', 'ꣻ'}, {'ꣽ', 'ꣾ'},
+	{'ꤊ', 'ꤥ'}, {'ꤰ', 'ꥆ'}, {'ꥠ', 'ꥼ'}, {'ꦄ', 'ꦲ'},
+	{'ꧏ', 'ꧏ'}, {'ꧠ', 'ꧤ'}, {'ꧦ', 'ꧯ'}, {'ꧺ', 'ꧾ'},
+	{'ꨀ', 'ꨨ'}, {'ꩀ', 'ꩂ'}, {'ꩄ', 'ꩋ'}, {'ꩠ', 'ꩶ'},
+	{'ꩺ', 'ꩺ'}, {'ꩾ', 'ꪯ'}, {'ꪱ', 'ꪱ'}, {'ꪵ', 'ꪶ'},
+	{'ꪹ', 'ꪽ'}, {'ꫀ', 'ꫀ'}, {'ꫂ', 'ꫂ'}, {'ꫛ', 'ꫝ'},
+	{'ꫠ', 'ꫪ'}, {'ꫲ', 'ꫴ'}, {'ꬁ', 'ꬆ'}, {'ꬉ', 'ꬎ'},
+	{'ꬑ', 'ꬖ'}, {'ꬠ', 'ꬦ'}, {'ꬨ', 'ꬮ'}, {'ꬰ', 'ꭚ'},
+	{'ꭜ', 'ꭩ'}, {'ꭰ', 'ꯢ'}, {'가', '힣'}, {'ힰ', 'ퟆ'},
+	{'ퟋ', 'ퟻ'}, {'豈', '舘'}, {'並', '龎'}, {'ﬀ', 'ﬆ'},
+	{'ﬓ', 'ﬗ'}, {'יִ', 'יִ'}, {'ײַ', 'ﬨ'}, {'שׁ', 'זּ'},
+	{'טּ', 'לּ'}, {'מּ', 'מּ'}, {'נּ', 'סּ'}, {'ףּ', 'פּ'},
+	{'צּ', 'ﮱ'}, {'ﯓ', 'ﴽ'}, {'ﵐ', 'ﶏ'}, {'ﶒ', 'ﷇ'},
+	{'ﷰ', 'ﷻ'}, {'ﹰ', 'ﹴ'}, {'ﹶ', 'ﻼ'}, {'Ａ', 'Ｚ'},
+	{'ａ', 'ｚ'}, {'ｦ', 'ﾾ'}, {'ￂ', 'ￇ'}, {'ￊ', 'ￏ'},
+	{'ￒ', 'ￗ'}, {'ￚ', 'ￜ'}, {'𐀀', '𐀋'}, {'𐀍', '𐀦'},
+	{'𐀨', '𐀺'}, {'𐀼', '𐀽'}, {'𐀿', '𐁍'}, {'𐁐', '𐁝'},
+	{'𐂀', '𐃺'}, {'𐊀', '𐊜'}, {'𐊠', '𐋐'}, {'𐌀', '𐌟'},
+	{'𐌭', '𐍀'}, {'𐍂', '𐍉'}, {'𐍐', '𐍵'}, {'𐎀', '𐎝'},
+	{'𐎠', '𐏃'}, {'𐏈', '𐏏'}, {'𐐀', '𐒝'}, {'𐒰', '𐓓'},
+	{'𐓘', '𐓻'}, {'𐔀', '𐔧'}, {'𐔰', '𐕣'}, {'𐕰', '𐕺'},
+	{'𐕼', '𐖊'}, {'𐖌', '𐖒'}, {'𐖔', '𐖕'}, {'𐖗', '𐖡'},
+	{'𐖣', '𐖱'}, {'𐖳', '𐖹'}, {'𐖻', '𐖼'}, {'𐗀', '𐗳'},
+	{'𐘀', '𐜶'}, {'𐝀', '𐝕'}, {'𐝠', '𐝧'}, {'𐞀', '𐞅'},
+	{'𐞇', '𐞰'}, {'𐞲', '𐞺'}, {'𐠀', '𐠅'}, {'𐠈', '𐠈'},
+	{'𐠊', '𐠵'}, {'𐠷', '𐠸'}, {'𐠼', '𐠼'}, {'𐠿', '𐡕'},
+	{'𐡠', '𐡶'}, {'𐢀', '𐢞'}, {'𐣠', '𐣲'}, {'𐣴', '𐣵'},
+	{'𐤀', '𐤕'}, {'𐤠', '𐤹'}, {'𐥀', '𐥙'}, {'𐦀', '𐦷'},
+	{'𐦾', '𐦿'}, {'𐨀', '𐨀'}, {'𐨐', '𐨓'}, {'𐨕', '𐨗'},
+	{'𐨙', '𐨵'}, {'𐩠', '𐩼'}, {'𐪀', '𐪜'}, {'𐫀', '𐫇'},
+	{'𐫉', '𐫤'}, {'𐬀', '𐬵'}, {'𐭀', '𐭕'}, {'𐭠', '𐭲'},
+	{'𐮀', '𐮑'}, {'𐰀', '𐱈'}, {'𐲀', '𐲲'}, {'𐳀', '𐳲'},
+	{'𐴀', '𐴣'}, {'𐵊', '𐵥'}, {'𐵯', '𐶅'}, {'𐺀', '𐺩'},
+	{'𐺰', '𐺱'}, {'𐻂', '𐻇'}, {'𐼀', '𐼜'}, {'𐼧', '𐼧'},
+	{'𐼰', '𐽅'}, {'𐽰', '𐾁'}, {'𐾰', '𐿄'}, {'𐿠', '𐿶'},
+	{'𑀃', '𑀷'}, {'𑁱', '𑁲'}, {'𑁵', '𑁵'}, {'𑂃', '𑂯'},
+	{'𑃐', '𑃨'}, {'𑄃', '𑄦'}, {'𑅄', '𑅄'}, {'𑅇', '𑅇'},
+	{'𑅐', '𑅲'}, {'𑅶', '𑅶'}, {'𑆃', '𑆲'}, {'𑇁', '𑇄'},
+	{'𑇚', '𑇚'}, {'𑇜', '𑇜'}, {'𑈀', '𑈑'}, {'𑈓', '𑈫'},
+	{'𑈿', '𑉀'}, {'𑊀', '𑊆'}, {'𑊈', '𑊈'}, {'𑊊', '𑊍'},
+	{'𑊏', '𑊝'}, {'𑊟', '𑊨'}, {'𑊰', '𑋞'}, {'𑌅', '𑌌'},
+	{'𑌏', '𑌐'}, {'𑌓', '𑌨'}, {'𑌪', '𑌰'}, {'𑌲', '𑌳'},
+	{'𑌵', '𑌹'}, {'𑌽', '𑌽'}, {'𑍐', '𑍐'}, {'𑍝', '𑍡'},
+	{'𑎀', '𑎉'}, {'𑎋', '𑎋'}, {'𑎎', '𑎎'}, {'𑎐', '𑎵'},
+	{'𑎷', '𑎷'}, {'𑏑', '𑏑'}, {'𑏓', '𑏓'}, {'𑐀', '𑐴'},
+	{'𑑇', '𑑊'}, {'𑑟', '𑑡'}, {'𑒀', '𑒯'}, {'𑓄', '𑓅'},
+	{'𑓇', '𑓇'}, {'𑖀', '𑖮'}, {'𑗘', '𑗛'}, {'𑘀', '𑘯'},
+	{'𑙄', '𑙄'}, {'𑚀', '𑚪'}, {'𑚸', '𑚸'}, {'𑜀', '𑜚'},
+	{'𑝀', '𑝆'}, {'𑠀', '𑠫'}, {'𑢠', '𑣟'}, {'𑣿', '𑤆'},
+	{'𑤉', '𑤉'}, {'𑤌', '𑤓'}, {'𑤕', '𑤖'}, {'𑤘', '𑤯'},
+	{'𑤿', '𑤿'}, {'𑥁', '𑥁'}, {'𑦠', '𑦧'}, {'𑦪', '𑧐'},
+	{'𑧡', '𑧡'}, {'𑧣', '𑧣'}, {'𑨀', '𑨀'}, {'𑨋', '𑨲'},
+	{'𑨺', '𑨺'}, {'𑩐', '𑩐'}, {'𑩜', '𑪉'}, {'𑪝', '𑪝'},
+	{'𑪰', '𑫸'}, {'𑯀', '𑯠'}, {'𑰀', '𑰈'}, {'𑰊', '𑰮'},
+	{'𑱀', '𑱀'}, {'𑱲', '𑲏'}, {'𑴀', '𑴆'}, {'𑴈', '𑴉'},
+	{'𑴋', '𑴰'}, {'𑵆', '𑵆'}, {'𑵠', '𑵥'}, {'𑵧', '𑵨'},
+	{'𑵪', '𑶉'}, {'𑶘', '𑶘'}, {'𑶰', '𑷛'}, {'𑻠', '𑻲'},
+	{'𑼂', '𑼂'}, {'𑼄', '𑼐'}, {'𑼒', '𑼳'}, {'𑾰', '𑾰'},
+	{'𒀀', '𒎙'}, {'𒒀', '𒕃'}, {'𒾐', '𒿰'}, {'𓀀', '𓐯'},
+	{'𓑁', '𓑆'}, {'𓑠', '𔏺'}, {'𔐀', '𔙆'}, {'𖄀', '𖄝'},
+	{'𖠀', '𖨸'}, {'𖩀', '𖩞'}, {'𖩰', '𖪾'}, {'𖫐', '𖫭'},
+	{'𖬀', '𖬯'}, {'𖭀', '𖭃'}, {'𖭣', '𖭷'}, {'𖭽', '𖮏'},
+	{'𖵀', '𖵬'}, {'𖹀', '𖹿'}, {'𖺠', '𖺸'}, {'𖺻', '𖻓'},
+	{'𖼀', '𖽊'}, {'𖽐', '𖽐'}, {'𖾓', '𖾟'}, {'𖿠', '𖿡'},
+	{'𖿣', '𖿣'}, {'𖿲', '𖿳'}, {'𗀀', '𘳕'}, {'𘳿', '𘴞'},
+	{'𘶀', '𘷲'}, {'𚿰', '𚿳'}, {'𚿵', '𚿻'}, {'𚿽', '𚿾'},
+	{'𛀀', '𛄢'}, {'𛄲', '𛄲'}, {'𛅐', '𛅒'}, {'𛅕', '𛅕'},
+	{'𛅤', '𛅧'}, {'𛅰', '𛋻'}, {'𛰀', '𛱪'}, {'𛱰', '𛱼'},
+	{'𛲀', '𛲈'}, {'𛲐', '𛲙'}, {'𝐀', '𝑔'}, {'𝑖', '𝒜'},
+	{'𝒞', '𝒟'}, {'𝒢', '𝒢'}, {'𝒥', '𝒦'}, {'𝒩', '𝒬'},
+	{'𝒮', '𝒹'}, {'𝒻', '𝒻'}, {'𝒽', '𝓃'}, {'𝓅', '𝔅'},
+	{'𝔇', '𝔊'}, {'𝔍', '𝔔'}, {'𝔖', '𝔜'}, {'𝔞', '𝔹'},
+	{'𝔻', '𝔾'}, {'𝕀', '𝕄'}, {'𝕆', '𝕆'}, {'𝕊', '𝕐'},
+	{'𝕒', '𝚥'}, {'𝚨', '𝛀'}, {'𝛂', '𝛚'}, {'𝛜', '𝛺'},
+	{'𝛼', '𝜔'}, {'𝜖', '𝜴'}, {'𝜶', '𝝎'}, {'𝝐', '𝝮'},
+	{'𝝰', '𝞈'}, {'𝞊', '𝞨'}, {'𝞪', '𝟂'}, {'𝟄', '𝟋'},
+	{'𝼀', '𝼞'}, {'𝼥', '𝼪'}, {'𞀰', '𞁭'}, {'𞄀', '𞄬'},
+	{'𞄷', '𞄽'}, {'𞅎', '𞅎'}, {'𞊐', '𞊭'}, {'𞋀', '𞋫'},
+	{'𞓐', '𞓫'}, {'𞗐', '𞗭'}, {'𞗰', '𞗰'}, {'𞛀', '𞛞'},
+	{'𞛠', '𞛢'}, {'𞛤', '𞛥'}, {'𞛧', '𞛭'}, {'𞛰', '𞛴'},
+	{'𞛾', '𞛿'}, {'𞟠', '𞟦'}, {'𞟨', '𞟫'}, {'𞟭', '𞟮'},
+	{'𞟰', '𞟾'}, {'𞠀', '𞣄'}, {'𞤀', '𞥃'}, {'𞥋', '𞥋'},
+	{'𞸀', '𞸃'}, {'𞸅', '𞸟'}, {'𞸡', '𞸢'}, {'𞸤', '𞸤'},
+	{'𞸧', '𞸧'}, {'𞸩', '𞸲'}, {'𞸴', '𞸷'}, {'𞸹', '𞸹'},
+	{'𞸻', '𞸻'}, {'𞹂', '𞹂'}, {'𞹇', '𞹇'}, {'𞹉', '𞹉'},
+	{'𞹋', '𞹋'}, {'𞹍', '𞹏'}, {'𞹑', '𞹒'}, {'𞹔', '𞹔'},
+	{'𞹗', '𞹗'}, {'𞹙', '𞹙'}, {'𞹛', '𞹛'}, {'𞹝', '𞹝'},
+	{'𞹟', '𞹟'}, {'𞹡', '𞹢'}, {'𞹤', '𞹤'}, {'𞹧', '𞹪'},
+	{'𞹬', '𞹲'}, {'𞹴', '𞹷'}, {'𞹹', '𞹼'}, {'𞹾', '𞹾'},
+	{'𞺀', '𞺉'}, {'𞺋', '𞺛'}, {'𞺡', '𞺣'}, {'𞺥', '𞺩'},
+	{'𞺫', '𞺻'}, {'𠀀', '𪛟'}, {'𪜀', '𫠝'}, {'𫠠', '𬺭'},
+	{'𬺰', '𮯠'}, {'𮯰', '𮹝'}, {'丽', '𪘀'}, {'𰀀', '𱍊'},
+	{'𱍐', '𳑹'},
+}
+
+// guardUnicodeIdentifier1 is [\p{L}\p{Nd}_].
+var guardUnicodeIdentifier1 = [][2]rune{
+	{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'},
+	{'ª', 'ª'}, {'µ', 'µ'}, {'º', 'º'}, {'À', 'Ö'},
+	{'Ø', 'ö'}, {'ø', 'ˁ'}, {'ˆ', 'ˑ'}, {'ˠ', 'ˤ'},
+	{'ˬ', 'ˬ'}, {'ˮ', 'ˮ'}, {'Ͱ', 'ʹ'}, {'Ͷ', 'ͷ'},
+	{'ͺ', 'ͽ'}, {'Ϳ', 'Ϳ'}, {'Ά', 'Ά'}, {'Έ', 'Ί'},
+	{'Ό', 'Ό'}, {'Ύ', 'Ρ'}, {'Σ', 'ϵ'}, {'Ϸ', 'ҁ'},
+	{'Ҋ', 'ԯ'}, {'Ա', 'Ֆ'}, {'ՙ', 'ՙ'}, {'ՠ', 'ֈ'},
+	{'א', 'ת'}, {'ׯ', 'ײ'}, {'ؠ', 'ي'}, {'٠', '٩'},
+	{'ٮ', 'ٯ'}, {'ٱ', 'ۓ'}, {'ە', 'ە'}, {'ۥ', 'ۦ'},
+	{'ۮ', 'ۼ'}, {'ۿ', 'ۿ'}, {'ܐ', 'ܐ'}, {'ܒ', 'ܯ'},
+	{'ݍ', 'ޥ'}, {'ޱ', 'ޱ'}, {'߀', 'ߪ'}, {'ߴ', 'ߵ'},
+	{'ߺ', 'ߺ'}, {'ࠀ', 'ࠕ'}, {'ࠚ', 'ࠚ'}, {'ࠤ', 'ࠤ'},
+	{'ࠨ', 'ࠨ'}, {'ࡀ', 'ࡘ'}, {'ࡠ', 'ࡪ'}, {'ࡰ', 'ࢇ'},
+	{'ࢉ', '࢏'}, {'ࢠ', 'ࣉ'}, {'ऄ', 'ह'}, {'ऽ', 'ऽ'},
+	{'ॐ', 'ॐ'}, {'क़', 'ॡ'}, {'०', '९'}, {'ॱ', 'ঀ'},
+	{'অ', 'ঌ'}, {'এ', 'ঐ'}, {'ও', 'ন'}, {'প', 'র'},
+	{'ল', 'ল'}, {'শ', 'হ'}, {'ঽ', 'ঽ'}, {'ৎ', 'ৎ'},
+	{'ড়', 'ঢ়'}, {'য়', 'ৡ'}, {'০', 'ৱ'}, {'ৼ', 'ৼ'},
+	{'ਅ', 'ਊ'}, {'ਏ', 'ਐ'}, {'ਓ', 'ਨ'}, {'ਪ', 'ਰ'},
+	{'ਲ', 'ਲ਼'}, {'ਵ', 'ਸ਼'}, {'ਸ', 'ਹ'}, {'ਖ਼', 'ੜ'},
+	{'ਫ਼', 'ਫ਼'}, {'੦', '੯'}, {'ੲ', 'ੴ'}, {'અ', 'ઍ'},
+	{'એ', 'ઑ'}, {'ઓ', 'ન'}, {'પ', 'ર'}, {'લ', 'ળ'},
+	{'વ', 'હ'}, {'ઽ', 'ઽ'}, {'ૐ', 'ૐ'}, {'ૠ', 'ૡ'},
+	{'૦', '૯'}, {'ૹ', 'ૹ'}, {'ଅ', 'ଌ'}, {'ଏ', 'ଐ'},
+	{'ଓ', 'ନ'}, {'ପ', 'ର'}, {'ଲ', 'ଳ'}, {'ଵ', 'ହ'},
+	{'ଽ', 'ଽ'}, {'ଡ଼', 'ଢ଼'}, {'ୟ', 'ୡ'}, {'୦', '୯'},
+	{'ୱ', 'ୱ'}, {'ஃ', 'ஃ'}, {'அ', 'ஊ'}, {'எ', 'ஐ'},
+	{'ஒ', 'க'}, {'ங', 'ச'}, {'ஜ', 'ஜ'}, {'ஞ', 'ட'},
+	{'ண', 'த'}, {'ந', 'ப'}, {'ம', 'ஹ'}, {'ௐ', 'ௐ'},
+	{'௦', '௯'}, {'అ', 'ఌ'}, {'ఎ', 'ఐ'}, {'ఒ', 'న'},
+	{'ప', 'హ'}, {'ఽ', 'ఽ'}, {'ౘ', 'ౚ'}, {'౜', 'ౝ'},
+	{'ౠ', 'ౡ'}, {'౦', '౯'}, {'ಀ', 'ಀ'}, {'ಅ', 'ಌ'},
+	{'ಎ', 'ಐ'}, {'ಒ', 'ನ'}, {'ಪ', 'ಳ'}, {'ವ', 'ಹ'},
+	{'ಽ', 'ಽ'}, {'೜', 'ೞ'}, {'ೠ', 'ೡ'}, {'೦', '೯'},
+	{'ೱ', 'ೲ'}, {'ഄ', 'ഌ'}, {'എ', 'ഐ'}, {'ഒ', 'ഺ'},
+	{'ഽ', 'ഽ'}, {'ൎ', 'ൎ'}, {'ൔ', 'ൖ'}, {'ൟ', 'ൡ'},
+	{'൦', '൯'}, {'ൺ', 'ൿ'}, {'අ', 'ඖ'}, {'ක', 'න'},
+	{'ඳ', 'ර'}, {'ල', 'ල'}, {'ව', 'ෆ'}, {'෦', '෯'},
+	{'ก', 'ะ'}, {'า', 'ำ'}, {'เ', 'ๆ'}, {'๐', '๙'},
+	{'ກ', 'ຂ'}, {'ຄ', 'ຄ'}, {'ຆ', 'ຊ'}, {'ຌ', 'ຣ'},
+	{'ລ', 'ລ'}, {'ວ', 'ະ'}, {'າ', 'ຳ'}, {'ຽ', 'ຽ'},
+	{'ເ', 'ໄ'}, {'ໆ', 'ໆ'}, {'໐', '໙'}, {'ໜ', 'ໟ'},
+	{'ༀ', 'ༀ'}, {'༠', '༩'}, {'ཀ', 'ཇ'}, {'ཉ', 'ཬ'},
+	{'ྈ', 'ྌ'}, {'က', 'ဪ'}, {'ဿ', '၉'}, {'ၐ', 'ၕ'},
+	{'ၚ', 'ၝ'}, {'ၡ', 'ၡ'}, {'ၥ', 'ၦ'}, {'ၮ', 'ၰ'},
+	{'ၵ', 'ႁ'}, {'ႎ', 'ႎ'}, {'႐', '႙'}, {'Ⴀ', 'Ⴥ'},
+	{'Ⴧ', 'Ⴧ'}, {'Ⴭ', 'Ⴭ'}, {'ა', 'ჺ'}, {'ჼ', 'ቈ'},
+	{'ቊ', 'ቍ'}, {'ቐ', 'ቖ'}, {'ቘ', 'ቘ'}, {'ቚ', 'ቝ'},
+	{'በ', 'ኈ'}, {'ኊ', 'ኍ'}, {'ነ', 'ኰ'}, {'ኲ', 'ኵ'},
+	{'ኸ', 'ኾ'}, {'ዀ', 'ዀ'}, {'ዂ', 'ዅ'}, {'ወ', 'ዖ'},
+	{'ዘ', 'ጐ'}, {'ጒ', 'ጕ'}, {'ጘ', 'ፚ'}, {'ᎀ', 'ᎏ'},
+	{'Ꭰ', 'Ᏽ'}, {'ᏸ', 'ᏽ'}, {'ᐁ', 'ᙬ'}, {'ᙯ', 'ᙿ'},
+	{'ᚁ', 'ᚚ'}, {'ᚠ', 'ᛪ'}, {'ᛱ', 'ᛸ'}, {'ᜀ', 'ᜑ'},
+	{'ᜟ', 'ᜱ'}, {'ᝀ', 'ᝑ'}, {'ᝠ', 'ᝬ'}, {'ᝮ', 'ᝰ'},
+	{'ក', 'ឳ'}, {'ៗ', 'ៗ'}, {'ៜ', 'ៜ'}, {'០', '៩'},
+	{'᠐', '᠙'}, {'ᠠ', 'ᡸ'}, {'ᢀ', 'ᢄ'}, {'ᢇ', 'ᢨ'},
+	{'ᢪ', 'ᢪ'}, {'ᢰ', 'ᣵ'}, {'ᤀ', 'ᤞ'}, {'᥆', 'ᥭ'},
+	{'ᥰ', 'ᥴ'}, {'ᦀ', 'ᦫ'}, {'ᦰ', 'ᧉ'}, {'᧐', '᧙'},
+	{'ᨀ', 'ᨖ'}, {'ᨠ', 'ᩔ'}, {'᪀', '᪉'}, {'᪐', '᪙'},
+	{'ᪧ', 'ᪧ'}, {'ᬅ', 'ᬳ'}, {'ᭅ', 'ᭌ'}, {'᭐', '᭙'},
+	{'ᮃ', 'ᮠ'}, {'ᮮ', 'ᯥ'}, {'ᰀ', 'ᰣ'}, {'᱀', '᱉'},
+	{'ᱍ', 'ᱽ'}, {'ᲀ', 'ᲊ'}, {'Ა', 'Ჺ'}, {'Ჽ', 'Ჿ'},
+	{'ᳩ', 'ᳬ'}, {'ᳮ', 'ᳳ'}, {'ᳵ', 'ᳶ'}, {'ᳺ', 'ᳺ'},
+	{'ᴀ', 'ᶿ'}, {'Ḁ', 'ἕ'}, {'Ἐ', 'Ἕ'}, {'ἠ', 'ὅ'},
+	{'Ὀ', 'Ὅ'}, {'ὐ', 'ὗ'}, {'Ὑ', 'Ὑ'}, {'Ὓ', 'Ὓ'},
+	{'Ὕ', 'Ὕ'}, {'Ὗ', 'ώ'}, {'ᾀ', 'ᾴ'}, {'ᾶ', 'ᾼ'},
+	{'ι', 'ι'}, {'ῂ', 'ῄ'}, {'ῆ', 'ῌ'}, {'ῐ', 'ΐ'},
+	{'ῖ', 'Ί'}, {'ῠ', 'Ῥ'}, {'ῲ', 'ῴ'}, {'ῶ', 'ῼ'},
+	{'ⁱ', 'ⁱ'}, {'ⁿ', 'ⁿ'}, {'ₐ', 'ₜ'}, {'ℂ', 'ℂ'},
+	{'ℇ', 'ℇ'}, {'ℊ', 'ℓ'}, {'ℕ', 'ℕ'}, {'ℙ', 'ℝ'},
+	{'ℤ', 'ℤ'}, {'Ω', 'Ω'}, {'ℨ', 'ℨ'}, {'K', 'ℭ'},
+	{'ℯ', 'ℹ'}, {'ℼ', 'ℿ'}, {'ⅅ', 'ⅉ'}, {'ⅎ', 'ⅎ'},
+	{'Ↄ', 'ↄ'}, {'Ⰰ', 'ⳤ'}, {'Ⳬ', 'ⳮ'}, {'Ⳳ', 'ⳳ'},
+	{'ⴀ', 'ⴥ'}, {'ⴧ', 'ⴧ'}, {'ⴭ', 'ⴭ'}, {'ⴰ', 'ⵧ'},
+	{'ⵯ', 'ⵯ'}, {'ⶀ', 'ⶖ'}, {'ⶠ', 'ⶦ'}, {'ⶨ', 'ⶮ'},
+	{'ⶰ', 'ⶶ'}, {'ⶸ', 'ⶾ'}, {'ⷀ', 'ⷆ'}, {'ⷈ', 'ⷎ'},
+	{'ⷐ', 'ⷖ'}, {'ⷘ', 'ⷞ'}, {'ⸯ', 'ⸯ'}, {'々', '〆'},
+	{'〱', '〵'}, {'〻', '〼'}, {'ぁ', 'ゖ'}, {'ゝ', 'ゟ'},
+	{'ァ', 'ヺ'}, {'ー', 'ヿ'}, {'ㄅ', 'ㄯ'}, {'ㄱ', 'ㆎ'},
+	{'ㆠ', 'ㆿ'}, {'ㇰ', 'ㇿ'}, {'㐀', '䶿'}, {'一', 'ꒌ'},
+	{'ꓐ', 'ꓽ'}, {'ꔀ', 'ꘌ'}, {'ꘐ', 'ꘫ'}, {'Ꙁ', 'ꙮ'},
+	{'ꙿ', 'ꚝ'}, {'ꚠ', 'ꛥ'}, {'ꜗ', 'ꜟ'}, {'Ꜣ', 'ꞈ'},
+	{'Ꞌ', 'Ƛ'}, {'꟱', 'ꠁ'}, {'ꠃ', 'ꠅ'}, {'ꠇ', 'ꠊ'},
+	{'ꠌ', 'ꠢ'}, {'ꡀ', 'ꡳ'}, {'ꢂ', 'ꢳ'}, {'꣐', '꣙'},
+	{'ꣲ', 'ꣷ'}, {'ꣻ', 'ꣻ'}, {'ꣽ', 'ꣾ'}, {'꤀', 'ꤥ'},
+	{'ꤰ', 'ꥆ'}, {'ꥠ', 'ꥼ'}, {'ꦄ', 'ꦲ'}, {'ꧏ', '꧙'},
+	{'ꧠ', 'ꧤ'}, {'ꧦ', 'ꧾ'}, {'ꨀ', 'ꨨ'}, {'ꩀ', 'ꩂ'},
+	{'ꩄ', 'ꩋ'}, {'꩐', '꩙'}, {'ꩠ', 'ꩶ'}, {'ꩺ', 'ꩺ'},
+	{'ꩾ', 'ꪯ'}, {'ꪱ', 'ꪱ'}, {'ꪵ', 'ꪶ'}, {'ꪹ', 'ꪽ'},
+	{'ꫀ', 'ꫀ'}, {'ꫂ', 'ꫂ'}, {'ꫛ', 'ꫝ'}, {'ꫠ', 'ꫪ'},
+	{'ꫲ', 'ꫴ'}, {'ꬁ', 'ꬆ'}, {'ꬉ', 'ꬎ'}, {'ꬑ', 'ꬖ'},
+	{'ꬠ', 'ꬦ'}, {'ꬨ', 'ꬮ'}, {'ꬰ', 'ꭚ'}, {'ꭜ', 'ꭩ'},
+	{'ꭰ', 'ꯢ'}, {'꯰', '꯹'}, {'가', '힣'}, {'ힰ', 'ퟆ'},
+	{'ퟋ', 'ퟻ'}, {'豈', '舘'}, {'並', '龎'}, {'ﬀ', 'ﬆ'},
+	{'ﬓ', 'ﬗ'}, {'יִ', 'יִ'}, {'ײַ', 'ﬨ'}, {'שׁ', 'זּ'},
+	{'טּ', 'לּ'}, {'מּ', 'מּ'}, {'נּ', 'סּ'}, {'ףּ', 'פּ'},
+	{'צּ', 'ﮱ'}, {'ﯓ', 'ﴽ'}, {'ﵐ', 'ﶏ'}, {'ﶒ', 'ﷇ'},
+	{'ﷰ', 'ﷻ'}, {'ﹰ', 'ﹴ'}, {'ﹶ', 'ﻼ'}, {'０', '９'},
+	{'Ａ', 'Ｚ'}, {'ａ', 'ｚ'}, {'ｦ', 'ﾾ'}, {'ￂ', 'ￇ'},
+	{'ￊ', 'ￏ'}, {'ￒ', 'ￗ'}, {'ￚ', 'ￜ'}, {'𐀀', '𐀋'},
+	{'𐀍', '𐀦'}, {'𐀨', '𐀺'}, {'𐀼', '𐀽'}, {'𐀿', '𐁍'},
+	{'𐁐', '𐁝'}, {'𐂀', '𐃺'}, {'𐊀', '𐊜'}, {'𐊠', '𐋐'},
+	{'𐌀', '𐌟'}, {'𐌭', '𐍀'}, {'𐍂', '𐍉'}, {'𐍐', '𐍵'},
+	{'𐎀', '𐎝'}, {'𐎠', '𐏃'}, {'𐏈', '𐏏'}, {'𐐀', '𐒝'},
+	{'𐒠', '𐒩'}, {'𐒰', '𐓓'}, {'𐓘', '𐓻'}, {'𐔀', '𐔧'},
+	{'𐔰', '𐕣'}, {'𐕰', '𐕺'}, {'𐕼', '𐖊'}, {'𐖌', '𐖒'},
+	{'𐖔', '𐖕'}, {'𐖗', '𐖡'}, {'𐖣', '𐖱'}, {'𐖳', '𐖹'},
+	{'𐖻', '𐖼'}, {'𐗀', '𐗳'}, {'𐘀', '𐜶'}, {'𐝀', '𐝕'},
+	{'𐝠', '𐝧'}, {'𐞀', '𐞅'}, {'𐞇', '𐞰'}, {'𐞲', '𐞺'},
+	{'𐠀', '𐠅'}, {'𐠈', '𐠈'}, {'𐠊', '𐠵'}, {'𐠷', '𐠸'},
+	{'𐠼', '𐠼'}, {'𐠿', '𐡕'}, {'𐡠', '𐡶'}, {'𐢀', '𐢞'},
+	{'𐣠', '𐣲'}, {'𐣴', '𐣵'}, {'𐤀', '𐤕'}, {'𐤠', '𐤹'},
+	{'𐥀', '𐥙'}, {'𐦀', '𐦷'}, {'𐦾', '𐦿'}, {'𐨀', '𐨀'},
+	{'𐨐', '𐨓'}, {'𐨕', '𐨗'}, {'𐨙', '𐨵'}, {'𐩠', '𐩼'},
+	{'𐪀', '𐪜'}, {'𐫀', '𐫇'}, {'𐫉', '𐫤'}, {'𐬀', '𐬵'},
+	{'𐭀', '𐭕'}, {'𐭠', '𐭲'}, {'𐮀', '𐮑'}, {'𐰀', '𐱈'},
+	{'𐲀', '𐲲'}, {'𐳀', '𐳲'}, {'𐴀', '𐴣'}, {'𐴰', '𐴹'},
+	{'𐵀', '𐵥'}, {'𐵯', '𐶅'}, {'𐺀', '𐺩'}, {'𐺰', '𐺱'},
+	{'𐻂', '𐻇'}, {'𐼀', '𐼜'}, {'𐼧', '𐼧'}, {'𐼰', '𐽅'},
+	{'𐽰', '𐾁'}, {'𐾰', '𐿄'}, {'𐿠', '𐿶'}, {'𑀃', '𑀷'},
+	{'𑁦', '𑁯'}, {'𑁱', '𑁲'}, {'𑁵', '𑁵'}, {'𑂃', '𑂯'},
+	{'𑃐', '𑃨'}, {'𑃰', '𑃹'}, {'𑄃', '𑄦'}, {'𑄶', '𑄿'},
+	{'𑅄', '𑅄'}, {'𑅇', '𑅇'}, {'𑅐', '𑅲'}, {'𑅶', '𑅶'},
+	{'𑆃', '𑆲'}, {'𑇁', '𑇄'}, {'𑇐', '𑇚'}, {'𑇜', '𑇜'},
+	{'𑈀', '𑈑'}, {'𑈓', '𑈫'}, {'𑈿', '𑉀'}, {'𑊀', '𑊆'},
+	{'𑊈', '𑊈'}, {'𑊊', '𑊍'}, {'𑊏', '𑊝'}, {'𑊟', '𑊨'},
+	{'𑊰', '𑋞'}, {'𑋰', '𑋹'}, {'𑌅', '𑌌'}, {'𑌏', '𑌐'},
+	{'𑌓', '𑌨'}, {'𑌪', '𑌰'}, {'𑌲', '𑌳'}, {'𑌵', '𑌹'},
+	{'𑌽', '𑌽'}, {'𑍐', '𑍐'}, {'𑍝', '𑍡'}, {'𑎀', '𑎉'},
+	{'𑎋', '𑎋'}, {'𑎎', '𑎎'}, {'𑎐', '𑎵'}, {'𑎷', '𑎷'},
+	{'𑏑', '𑏑'}, {'𑏓', '𑏓'}, {'𑐀', '𑐴'}, {'𑑇', '𑑊'},
+	{'𑑐', '𑑙'}, {'𑑟', '𑑡'}, {'𑒀', '𑒯'}, {'𑓄', '𑓅'},
+	{'𑓇', '𑓇'}, {'𑓐', '𑓙'}, {'𑖀', '𑖮'}, {'𑗘', '𑗛'},
+	{'𑘀', '𑘯'}, {'𑙄', '𑙄'}, {'𑙐', '𑙙'}, {'𑚀', '𑚪'},
+	{'𑚸', '𑚸'}, {'𑛀', '𑛉'}, {'𑛐', '𑛣'}, {'𑜀', '𑜚'},
+	{'𑜰', '𑜹'}, {'𑝀', '𑝆'}, {'𑠀', '𑠫'}, {'𑢠', '𑣩'},
+	{'𑣿', '𑤆'}, {'𑤉', '𑤉'}, {'𑤌', '𑤓'}, {'𑤕', '𑤖'},
+	{'𑤘', '𑤯'}, {'𑤿', '𑤿'}, {'𑥁', '𑥁'}, {'𑥐', '𑥙'},
+	{'𑦠', '𑦧'}, {'𑦪', '𑧐'}, {'𑧡', '𑧡'}, {'𑧣', '𑧣'},
+	{'𑨀', '𑨀'}, {'𑨋', '𑨲'}, {'𑨺', '𑨺'}, {'𑩐', '𑩐'},
+	{'𑩜', '𑪉'}, {'𑪝', '𑪝'}, {'𑪰', '𑫸'}, {'𑯀', '𑯠'},
+	{'𑯰', '𑯹'}, {'𑰀', '𑰈'}, {'𑰊', '𑰮'}, {'𑱀', '𑱀'},
+	{'𑱐', '𑱙'}, {'𑱲', '𑲏'}, {'𑴀', '𑴆'}, {'𑴈', '𑴉'},
+	{'𑴋', '𑴰'}, {'𑵆', '𑵆'}, {'𑵐', '𑵙'}, {'𑵠', '𑵥'},
+	{'𑵧', '𑵨'}, {'𑵪', '𑶉'}, {'𑶘', '𑶘'}, {'𑶠', '𑶩'},
+	{'𑶰', '𑷛'}, {'𑷠', '𑷩'}, {'𑻠', '𑻲'}, {'𑼂', '𑼂'},
+	{'𑼄', '𑼐'}, {'𑼒', '𑼳'}, {'𑽐', '𑽙'}, {'𑾰', '𑾰'},
+	{'𒀀', '𒎙'}, {'𒒀', '𒕃'}, {'𒾐', '𒿰'}, {'𓀀', '𓐯'},
+	{'𓑁', '𓑆'}, {'𓑠', '𔏺'}, {'𔐀', '𔙆'}, {'𖄀', '𖄝'},
+	{'𖄰', '𖄹'}, {'𖠀', '𖨸'}, {'𖩀', '𖩞'}, {'𖩠', '𖩩'},
+	{'𖩰', '𖪾'}, {'𖫀', '𖫉'}, {'𖫐', '𖫭'}, {'𖬀', '𖬯'},
+	{'𖭀', '𖭃'}, {'𖭐', '𖭙'}, {'𖭣', '𖭷'}, {'𖭽', '𖮏'},
+	{'𖵀', '𖵬'}, {'𖵰', '𖵹'}, {'𖹀', '𖹿'}, {'𖺠', '𖺸'},
+	{'𖺻', '𖻓'}, {'𖼀', '𖽊'}, {'𖽐', '𖽐'}, {'𖾓', '𖾟'},
+	{'𖿠', '𖿡'}, {'𖿣', '𖿣'}, {'𖿲', '𖿳'}, {'𗀀', '𘳕'},
+	{'𘳿', '𘴞'}, {'𘶀', '𘷲'}, {'𚿰', '𚿳'}, {'𚿵', '𚿻'},
+	{'𚿽', '𚿾'}, {'𛀀', '𛄢'}, {'𛄲', '𛄲'}, {'𛅐', '𛅒'},
+	{'𛅕', '𛅕'}, {'𛅤', '𛅧'}, {'𛅰', '𛋻'}, {'𛰀', '𛱪'},
+	{'𛱰', '𛱼'}, {'𛲀', '𛲈'}, {'𛲐', '𛲙'}, {'𜳰', '𜳹'},
+	{'𝐀', '𝑔'}, {'𝑖', '𝒜'}, {'𝒞', '𝒟'}, {'𝒢', '𝒢'},
+	{'𝒥', '𝒦'}, {'𝒩', '𝒬'}, {'𝒮', '𝒹'}, {'𝒻', '𝒻'},
+	{'𝒽', '𝓃'}, {'𝓅', '𝔅'}, {'𝔇', '𝔊'}, {'𝔍', '𝔔'},
+	{'𝔖', '𝔜'}, {'𝔞', '𝔹'}, {'𝔻', '𝔾'}, {'𝕀', '𝕄'},
+	{'𝕆', '𝕆'}, {'𝕊', '𝕐'}, {'𝕒', '𝚥'}, {'𝚨', '𝛀'},
+	{'𝛂', '𝛚'}, {'𝛜', '𝛺'}, {'𝛼', '𝜔'}, {'𝜖', '𝜴'},
+	{'𝜶', '𝝎'}, {'𝝐', '𝝮'}, {'𝝰', '𝞈'}, {'𝞊', '𝞨'},
+	{'𝞪', '𝟂'}, {'𝟄', '𝟋'}, {'𝟎', '𝟿'}, {'𝼀', '𝼞'},
+	{'𝼥', '𝼪'}, {'𞀰', '𞁭'}, {'𞄀', '𞄬'}, {'𞄷', '𞄽'},
+	{'𞅀', '𞅉'}, {'𞅎', '𞅎'}, {'𞊐', '𞊭'}, {'𞋀', '𞋫'},
+	{'𞋰', '𞋹'}, {'𞓐', '𞓫'}, {'𞓰', '𞓹'}, {'𞗐', '𞗭'},
+	{'𞗰', '𞗺'}, {'𞛀', '𞛞'}, {'𞛠', '𞛢'}, {'𞛤', '𞛥'},
+	{'𞛧', '𞛭'}, {'𞛰', '𞛴'}, {'𞛾', '𞛿'}, {'𞟠', '𞟦'},
+	{'𞟨', '𞟫'}, {'𞟭', '𞟮'}, {'𞟰', '𞟾'}, {'𞠀', '𞣄'},
+	{'𞤀', '𞥃'}, {'𞥋', '𞥋'}, {'𞥐', '𞥙'}, {'𞸀', '𞸃'},
+	{'𞸅', '𞸟'}, {'𞸡', '𞸢'}, {'𞸤', '𞸤'}, {'𞸧', '𞸧'},
+	{'𞸩', '𞸲'}, {'𞸴', '𞸷'}, {'𞸹', '𞸹'}, {'𞸻', '𞸻'},
+	{'𞹂', '𞹂'}, {'𞹇', '𞹇'}, {'𞹉', '𞹉'}, {'𞹋', '𞹋'},
+	{'𞹍', '𞹏'}, {'𞹑', '𞹒'}, {'𞹔', '𞹔'}, {'𞹗', '𞹗'},
+	{'𞹙', '𞹙'}, {'𞹛', '𞹛'}, {'𞹝', '𞹝'}, {'𞹟', '𞹟'},
+	{'𞹡', '𞹢'}, {'𞹤', '𞹤'}, {'𞹧', '𞹪'}, {'𞹬', '𞹲'},
+	{'𞹴', '𞹷'}, {'𞹹', '𞹼'}, {'𞹾', '𞹾'}, {'𞺀', '𞺉'},
+	{'𞺋', '𞺛'}, {'𞺡', '𞺣'}, {'𞺥', '𞺩'}, {'𞺫', '𞺻'},
+	{'🯰', '🯹'}, {'𠀀', '𪛟'}, {'𪜀', '𫠝'}, {'𫠠', '𬺭'},
+	{'𬺰', '𮯠'}, {'𮯰', '𮹝'}, {'丽', '𪘀'}, {'𰀀', '𱍊'},
+	{'𱍐', '𳑹'},
+}
+
+// stepUnicodeIdentifier returns the next state, or -1 when there is no transition.
+func stepUnicodeIdentifier(state int, r rune) int {
+	switch state {
+	case 0:
+		switch {
+		case inRangesUnicodeIdentifier(r, guardUnicodeIdentifier0):
+			return 1
+		}
+	case 1:
+		switch {
+		case inRangesUnicodeIdentifier(r, guardUnicodeIdentifier1):
+			return 1
+		}
+	}
+	return -1
+}
+
+func acceptingUnicodeIdentifier(state int) bool {
+	switch state {
+	case 1:
+		return true
+	}
+	return false
+}
+
+// MatchUnicodeIdentifier reports whether the whole of s is accepted.
+func MatchUnicodeIdentifier(s string) bool {
+	state := 0
+	for _, r := range s {
+		if state = stepUnicodeIdentifier(state, r); state < 0 {
+			return false
+		}
+	}
+	return acceptingUnicodeIdentifier(state)
+}
+
+// LongestPrefixUnicodeIdentifier returns the length in bytes of the longest accepted prefix of s,
+// or -1 when no prefix is accepted.
+func LongestPrefixUnicodeIdentifier(s string) int {
+	longest := -1
+	state := 0
+	if acceptingUnicodeIdentifier(state) {
+		longest = 0
+	}
+	for i, r := range s {
+		if state = stepUnicodeIdentifier(state, r); state < 0 {
+			break
+		}
+		if acceptingUnicodeIdentifier(state) {
+			longest = i + len(string(r))
+		}
+	}
+	return longest
+}
@@ -0,0 +1,148 @@
+// Code generated by fa2go from integer.json; DO NOT EDIT.
+
+package lexer
+
+// States of the "Literal întreg Go" matcher:
+//	0: q0
+//	1: q1
+//	2: q2
+//	3: q3
+//	4: q5
+//	5: q7
+//	6: q9
+//	7: q4
+//	8: q6
+//	9: q8
+//	10: q10
+//	11: q11
+//	12: q12
+
+// stepInteger returns the next state, or -1 when there is no transition.
+func stepInteger(state int, r rune) int {
+	switch state {
+	case 0:
+		switch r {
+		case '0':
+			return 1
+		case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 2
+		}
+	case 1:
+		switch r {
+		case 'B', 'b':
+			return 3
+		case 'O', 'o':
+			return 4
+		case 'X', 'x':
+			return 5
+		}
+	case 2:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 2
+		case '_':
+			return 6
+		}
+	case 3:
+		switch r {
+		case '_':
+			return 3
+		case '0', '1':
+			return 7
+		}
+	case 4:
+		switch r {
+		case '_':
+			return 4
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			return 8
+		}
+	case 5:
+		switch r {
+		case '_':
+			return 5
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 9
+		}
+	case 6:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			return 2
+		}
+	case 7:
+		switch r {
+		case '0', '1':
+			return 7
+		case '_':
+			return 10
+		}
+	case 8:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			return 8
+		case '_':
+			return 11
+		}
+	case 9:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 9
+		case '_':
+			return 12
+		}
+	case 10:
+		switch r {
+		case '0', '1':
+			return 7
+		}
+	case 11:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			return 8
+		}
+	case 12:
+		switch r {
+		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'A', 'B', 'C', 'D', 'E', 'F', 'a', 'b', 'c', 'd', 'e', 'f':
+			return 9
+		}
+	}
+	return -1
+}
+
+func acceptingInteger(state int) bool {
+	switch state {
+	case 1, 2, 7, 8, 9:
+		return true
+	}
+	return false
+}
+
+// MatchInteger reports whether the whole of s is accepted.
+func MatchInteger(s string) bool {
+	state := 0
+	for _, r := range s {
+		if state = stepInteger(state, r); state < 0 {
+			return false
+		}
+	}
+	return acceptingInteger(state)
+}
+
+// LongestPrefixInteger returns the length in bytes of the longest accepted prefix of s,
+// or -1 when no prefix is accepted.
+func LongestPrefixInteger(s string) int {
+	longest := -1
+	state := 0
+	if acceptingInteger(state) {
+		longest = 0
+	}
+	for i, r := range s {
+		if state = stepInteger(state, r); state < 0 {
+			break
+		}
+		if acceptingInteger(state) {
+			longest = i + len(string(r))
+		}
+	}
+	return longest
+}
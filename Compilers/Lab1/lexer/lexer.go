@@ -2,6 +2,7 @@ package lexer
 
 import (
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/bujor/compilers/shared/automaton"
 )
@@ -14,9 +15,9 @@ type Lexer struct {
 	line         int
 	column       int
 
-	identifierFA *automaton.FiniteAutomaton
-	integerFA    *automaton.FiniteAutomaton
-	floatFA      *automaton.FiniteAutomaton
+	identifier Matcher
+	integer    Matcher
+	float      Matcher
 }
 
 func New(input string) *Lexer {
@@ -30,13 +31,24 @@ func New(input string) *Lexer {
 }
 
 func NewWithAutomata(input string, identifierFA, integerFA, floatFA *automaton.FiniteAutomaton) *Lexer {
+	return NewWithMatchers(input, AutomatonMatcher(identifierFA), AutomatonMatcher(integerFA), AutomatonMatcher(floatFA))
+}
+
+// NewWithGenerated uses the matchers generated by fa2go from the definitions
+// in shared/automaton, so no JSON has to be parsed per lexer; identifiers are
+// read with the symbolic Unicode definition.
+func NewWithGenerated(input string) *Lexer {
+	return NewWithMatchers(input, LongestPrefixUnicodeIdentifier, LongestPrefixInteger, LongestPrefixFloat)
+}
+
+func NewWithMatchers(input string, identifier, integer, float Matcher) *Lexer {
 	l := &Lexer{
-		input:        input,
-		line:         1,
-		column:       0,
-		identifierFA: identifierFA,
-		integerFA:    integerFA,
-		floatFA:      floatFA,
+		input:      input,
+		line:       1,
+		column:     0,
+		identifier: identifier,
+		integer:    integer,
+		float:      float,
 	}
 	l.readChar()
 	return l
@@ -188,13 +200,13 @@ func (l *Lexer) NextToken() Token {
 	case ';':
 		tok = Token{Type: SEMICOLON, Literal: string(l.ch), Line: tok.Line, Column: tok.Column}
 	case '.':
-		if l.floatFA != nil {
+		if l.float != nil {
 			remainingInput := l.input[l.position:]
-			prefix, result := l.floatFA.LongestPrefix(remainingInput)
-			if prefix != "" && result.Accepted {
+			prefix := longestPrefix(l.float, remainingInput)
+			if prefix != "" {
 				tok.Type = FLOAT
 				tok.Literal = prefix
-				for range prefix {
+				for range len(prefix) {
 					l.readChar()
 				}
 				return tok
@@ -212,14 +224,14 @@ func (l *Lexer) NextToken() Token {
 	case 0:
 		tok = Token{Type: EOF, Literal: "", Line: tok.Line, Column: tok.Column}
 	default:
-		if isLetter(l.ch) || l.ch == '_' {
-			if l.identifierFA != nil {
+		if l.atIdentifierStart() {
+			if l.identifier != nil {
 				remainingInput := l.input[l.position:]
-				prefix, result := l.identifierFA.LongestPrefix(remainingInput)
-				if prefix != "" && result.Accepted {
+				prefix := longestPrefix(l.identifier, remainingInput)
+				if prefix != "" {
 					tok.Literal = prefix
 					tok.Type = LookupIdentifier(tok.Literal)
-					for range prefix {
+					for range len(prefix) {
 						l.readChar()
 					}
 					return tok
@@ -230,11 +242,11 @@ func (l *Lexer) NextToken() Token {
 				return tok
 			}
 		} else if isDigit(l.ch) {
-			if l.floatFA != nil && l.integerFA != nil {
+			if l.float != nil && l.integer != nil {
 				remainingInput := l.input[l.position:]
 
-				floatPrefix, floatResult := l.floatFA.LongestPrefix(remainingInput)
-				intPrefix, intResult := l.integerFA.LongestPrefix(remainingInput)
+				floatPrefix := longestPrefix(l.float, remainingInput)
+				intPrefix := longestPrefix(l.integer, remainingInput)
 
 				testLiteral := l.peekNumberLike()
 
@@ -247,14 +259,14 @@ func (l *Lexer) NextToken() Token {
 					return tok
 				}
 
-				if len(floatPrefix) > len(intPrefix) && floatResult.Accepted {
+				if len(floatPrefix) > len(intPrefix) {
 					tok.Type = FLOAT
 					tok.Literal = floatPrefix
 					for range floatPrefix {
 						l.readChar()
 					}
 					return tok
-				} else if intResult.Accepted {
+				} else if intPrefix != "" {
 					tok.Type = INT
 					tok.Literal = intPrefix
 					for range intPrefix {
@@ -263,10 +275,10 @@ func (l *Lexer) NextToken() Token {
 
 					if l.ch == '.' {
 						testInput := intPrefix + string(l.input[l.position:])
-						testFloatPrefix, testFloatResult := l.floatFA.LongestPrefix(testInput)
+						testFloatPrefix := longestPrefix(l.float, testInput)
 
 						nextChar := l.peekChar()
-						if !testFloatResult.Accepted || len(testFloatPrefix) <= len(intPrefix) {
+						if len(testFloatPrefix) <= len(intPrefix) {
 							if isDigit(nextChar) || nextChar == '_' || nextChar == 'e' || nextChar == 'E' {
 								invalidLiteral := intPrefix + "."
 								l.readChar() // consume '.'
@@ -420,6 +432,13 @@ func isLetter(ch byte) bool {
 	return unicode.IsLetter(rune(ch))
 }
 
+// atIdentifierStart reports whether the rune at the current position, which
+// may span several bytes, can start an identifier.
+func (l *Lexer) atIdentifierStart() bool {
+	r, _ := utf8.DecodeRuneInString(l.input[l.position:])
+	return unicode.IsLetter(r) || r == '_'
+}
+
 func isDigit(ch byte) bool {
 	return '0' <= ch && ch <= '9'
 }
@@ -0,0 +1,42 @@
+package lexer
+
+import (
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+//go:generate go run github.com/bujor/compilers/shared/automaton/cmd/fa2go -pkg lexer -name Identifier -o identifier_gen.go ../../shared/automaton/definitions/identifier.json
+//go:generate go run github.com/bujor/compilers/shared/automaton/cmd/fa2go -pkg lexer -name Integer -o integer_gen.go ../../shared/automaton/definitions/integer.json
+//go:generate go run github.com/bujor/compilers/shared/automaton/cmd/fa2go -pkg lexer -name Float -o float_gen.go ../../shared/automaton/definitions/float.json
+//go:generate go run github.com/bujor/compilers/shared/automaton/cmd/fa2go -pkg lexer -name UnicodeIdentifier -o identifier_unicode_gen.go ../../shared/automaton/definitions/identifier_unicode.json
+
+// Matcher returns the length in bytes of the longest prefix of input it
+// accepts, or -1 when there is none. The generated LongestPrefix* functions
+// are matchers; LongestPrefixUnicodeIdentifier accepts identifiers made of
+// any Unicode letters and decimal digits, as the Go spec allows, while
+// LongestPrefixIdentifier only knows the ASCII alphabet of identifier.json.
+type Matcher func(input string) int
+
+// AutomatonMatcher adapts an automaton loaded at runtime.
+func AutomatonMatcher(fa *automaton.FiniteAutomaton) Matcher {
+	return func(input string) int {
+		prefix, result := fa.LongestPrefix(input)
+		if !result.Accepted {
+			return -1
+		}
+		return len(prefix)
+	}
+}
+
+// SymbolicMatcher adapts an automaton whose transitions are rune predicates.
+func SymbolicMatcher(sa *automaton.SymbolicAutomaton) Matcher {
+	return sa.LongestPrefix
+}
+
+// longestPrefix returns the longest non-empty prefix of input accepted by
+// match, or "" when there is none.
+func longestPrefix(match Matcher, input string) string {
+	if n := match(input); n > 0 {
+		return input[:n]
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+//go:build !wasm
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func displayAnalysis(fa *automaton.FiniteAutomaton) {
+	analysis := fa.Analyze()
+
+	fmt.Println("=== Analiză Structurală ===")
+	fmt.Println()
+
+	fmt.Printf("Componente tare conexe (%d):\n", len(analysis.Components))
+	for i, component := range analysis.Components {
+		fmt.Printf("  C%d: {%s}\n", i+1, strings.Join(component, ", "))
+	}
+
+	if !analysis.Complete {
+		fmt.Print("\nAutomat de permutare: nu se aplică (necesită un AFD complet)\n")
+		fmt.Print("Automat de resetare: nu se aplică (necesită un AFD complet)\n")
+		fmt.Print("Cuvânt de sincronizare: nu se aplică (necesită un AFD complet)\n\n")
+		return
+	}
+
+	fmt.Printf("\nAutomat de permutare: %s\n", yesNo(analysis.Permutation))
+	fmt.Printf("Automat de resetare: %s\n", yesNo(analysis.Reset))
+
+	if !analysis.Synchronizing {
+		fmt.Print("Cuvânt de sincronizare: nu există\n\n")
+		return
+	}
+
+	method := "greedy (Eppstein)"
+	if analysis.ShortestWord {
+		method = "cel mai scurt"
+	}
+	fmt.Printf("Cuvânt de sincronizare: %s (%s, lungime %d)\n\n",
+		displayWord(analysis.SynchronizingWord), method, len([]rune(analysis.SynchronizingWord)))
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "da"
+	}
+	return "nu"
+}
+
+func displayTableFilling(fa *automaton.FiniteAutomaton) {
+	table, err := fa.TableFilling()
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n", err)
+		fmt.Print("Aplicați mai întâi construcția submulțimilor (opțiunea 24).\n\n")
+		return
+	}
+
+	fmt.Println()
+	fmt.Println(table.String())
+}
+
+// displayWeight shows the weight of an input on a weighted automaton and its
+// best accepting path.
+func displayWeight(fa *automaton.FiniteAutomaton, sequence string, weight float64) {
+	semiring, _ := automaton.SemiringByName(fa.Semiring)
+	fmt.Printf("Pondere (%s): %s\n", semiring.Name, automaton.FormatWeight(weight))
+	if path, found := fa.MostLikelyPath(sequence); found {
+		fmt.Printf("Cea mai bună cale: %s (pondere %s)\n",
+			strings.Join(path.States, " → "), automaton.FormatWeight(path.Weight))
+	}
+	fmt.Println()
+}
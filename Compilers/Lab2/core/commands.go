@@ -0,0 +1,414 @@
+//go:build !wasm
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+// Exit codes of the non-interactive commands.
+const (
+	exitOK      = 0
+	exitFailure = 1 // the command ran, but the answer is negative
+	exitError   = 2 // invalid usage or unreadable input
+)
+
+// runCommand executes a command given on the command line instead of
+// starting the interactive menu.
+func runCommand(args []string) int {
+	switch args[0] {
+	case "diff":
+		return diffCommand(args[1:])
+	case "test":
+		return testCommand(args[1:])
+	case "info":
+		return infoCommand(args[1:])
+	case "simulate":
+		return simulateCommand(args[1:])
+	case "prefix":
+		return prefixCommand(args[1:])
+	case "validate":
+		return validateCommand(args[1:])
+	case "convert":
+		return convertCommand(args[1:])
+	case "help", "-h", "--help":
+		printUsage()
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "Comandă necunoscută: %s\n\n", args[0])
+		printUsage()
+		return exitError
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Utilizare:")
+	fmt.Fprintln(os.Stderr, "  cli                              meniul interactiv")
+	fmt.Fprintln(os.Stderr, "  cli info [--json] fa.json        descrierea automatului")
+	fmt.Fprintln(os.Stderr, "  cli simulate [--trace] [--json] [--] fa.json cuvânt")
+	fmt.Fprintln(os.Stderr, "                                   verifică dacă cuvântul este acceptat")
+	fmt.Fprintln(os.Stderr, "  cli prefix [--json] [--] fa.json cuvânt")
+	fmt.Fprintln(os.Stderr, "                                   cel mai lung prefix acceptat")
+	fmt.Fprintln(os.Stderr, "  cli validate [--json] fa.json... validează automatele și rulează testele încorporate")
+	fmt.Fprintln(os.Stderr, "  cli convert --to dot|json [-o fișier] fa.json")
+	fmt.Fprintln(os.Stderr, "                                   conversia în Graphviz DOT sau în formatul JSON curent")
+	fmt.Fprintln(os.Stderr, "  cli diff [--iso] a.json b.json   diferențele structurale dintre două automate")
+	fmt.Fprintln(os.Stderr, "  cli test [--junit r.xml] fa.json... cazuri.txt")
+	fmt.Fprintln(os.Stderr, "                                   rulează cazurile de test pe fiecare automat")
+}
+
+// diffCommand exits with 0 when the automata are identical and 1 otherwise,
+// like diff(1).
+func diffCommand(args []string) int {
+	flags, files, ok := commandArgs(args, 2, []string{"--iso"}, nil)
+	if !ok {
+		return exitError
+	}
+	_, isomorphic := flags["--iso"]
+
+	a, ok := loadCommandAutomaton(files[0])
+	if !ok {
+		return exitError
+	}
+	b, ok := loadCommandAutomaton(files[1])
+	if !ok {
+		return exitError
+	}
+
+	var diff *automaton.AutomatonDiff
+	if isomorphic {
+		diff = automaton.DiffIsomorphic(a, b)
+	} else {
+		diff = automaton.Diff(a, b)
+	}
+
+	fmt.Print(diff.String())
+	if diff.IsEmpty() {
+		return exitOK
+	}
+	return exitFailure
+}
+
+// parseArgs separates flags from positional arguments. The flags in valued
+// take a value, given as "--flag value" or "--flag=value"; any other flag
+// not in known is an error. A flag starts with "--" or with "-" and a
+// letter, so words such as "-1" are positional; after "--" every argument
+// is positional.
+func parseArgs(args []string, known []string, valued []string) (map[string]string, []string, error) {
+	flags := make(map[string]string)
+	positional := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !isFlag(arg) {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(arg, "=")
+		switch {
+		case contains(valued, name):
+			if !hasValue {
+				if i+1 == len(args) {
+					return nil, nil, fmt.Errorf("opțiunea %s necesită o valoare", name)
+				}
+				value = args[i+1]
+				i++
+			}
+			flags[name] = value
+		case contains(known, name) && !hasValue:
+			flags[name] = ""
+		default:
+			return nil, nil, fmt.Errorf("opțiune necunoscută: %s (un cuvânt care începe cu '-' se scrie după --)", arg)
+		}
+	}
+	return flags, positional, nil
+}
+
+func isFlag(arg string) bool {
+	if strings.HasPrefix(arg, "--") {
+		return true
+	}
+	return len(arg) > 1 && arg[0] == '-' && unicode.IsLetter(rune(arg[1]))
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// commandArgs parses the arguments of a command that expects exactly count
+// positional arguments, printing the usage when they are wrong.
+func commandArgs(args []string, count int, known []string, valued []string) (map[string]string, []string, bool) {
+	flags, positional, err := parseArgs(args, known, valued)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Eroare: %v\n\n", err)
+		printUsage()
+		return nil, nil, false
+	}
+	if count >= 0 && len(positional) != count || count < 0 && len(positional) == 0 {
+		printUsage()
+		return nil, nil, false
+	}
+	return flags, positional, true
+}
+
+func loadCommandAutomaton(filename string) (*automaton.FiniteAutomaton, bool) {
+	fa, err := automaton.ParseFromFile(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Eroare (%s): %v\n", filename, err)
+		return nil, false
+	}
+	return fa, true
+}
+
+func printJSON(value interface{}) int {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Eroare la serializarea JSON: %v\n", err)
+		return exitError
+	}
+	fmt.Println(string(data))
+	return exitOK
+}
+
+// automatonInfo is the JSON output of the info command.
+type automatonInfo struct {
+	Name          string   `json:"name,omitempty"`
+	Description   string   `json:"description,omitempty"`
+	Type          string   `json:"type"`
+	Deterministic bool     `json:"deterministic"`
+	Weighted      bool     `json:"weighted"`
+	States        []string `json:"states"`
+	Alphabet      []string `json:"alphabet"`
+	InitialState  string   `json:"initialState"`
+	FinalStates   []string `json:"finalStates"`
+	Transitions   int      `json:"transitions"`
+	Tests         int      `json:"tests"`
+}
+
+func infoCommand(args []string) int {
+	flags, files, ok := commandArgs(args, 1, []string{"--json"}, nil)
+	if !ok {
+		return exitError
+	}
+	fa, ok := loadCommandAutomaton(files[0])
+	if !ok {
+		return exitError
+	}
+
+	if _, asJSON := flags["--json"]; asJSON {
+		return printJSON(automatonInfo{
+			Name:          fa.Name,
+			Description:   fa.Description,
+			Type:          fa.TypeString(),
+			Deterministic: fa.IsDeterministic(),
+			Weighted:      fa.IsWeighted(),
+			States:        fa.States,
+			Alphabet:      fa.Alphabet,
+			InitialState:  fa.InitialState,
+			FinalStates:   fa.FinalStates,
+			Transitions:   len(fa.TransitionList()),
+			Tests:         len(fa.Tests),
+		})
+	}
+
+	fmt.Print(fa.String())
+	return exitOK
+}
+
+// simulateCommand exits with 0 when the word is accepted and 1 when it is
+// rejected. The JSON output is the SimulationResult, steps included.
+func simulateCommand(args []string) int {
+	flags, positional, ok := commandArgs(args, 2, []string{"--json", "--trace"}, nil)
+	if !ok {
+		return exitError
+	}
+	fa, ok := loadCommandAutomaton(positional[0])
+	if !ok {
+		return exitError
+	}
+
+	word := positional[1]
+	if word == automaton.Epsilon {
+		word = ""
+	}
+	result := fa.SimulateWithRepair(word)
+
+	code := exitOK
+	if !result.Accepted {
+		code = exitFailure
+	}
+
+	if _, asJSON := flags["--json"]; asJSON {
+		if printJSON(result) != exitOK {
+			return exitError
+		}
+		return code
+	}
+
+	if result.Error != nil {
+		displayError(result.Error)
+	} else {
+		fmt.Println("ACCEPTAT")
+	}
+	if result.Weight != nil {
+		displayWeight(fa, word, *result.Weight)
+	}
+	if _, trace := flags["--trace"]; trace {
+		displaySteps(result.Steps, word)
+	}
+	return code
+}
+
+// prefixResult is the JSON output of the prefix command.
+type prefixResult struct {
+	Found  bool                        `json:"found"`
+	Prefix string                      `json:"prefix"`
+	Length int                         `json:"length"`
+	Result *automaton.SimulationResult `json:"result,omitempty"`
+}
+
+// prefixCommand exits with 0 when some non-empty prefix is accepted and 1
+// otherwise.
+func prefixCommand(args []string) int {
+	flags, positional, ok := commandArgs(args, 2, []string{"--json"}, nil)
+	if !ok {
+		return exitError
+	}
+	fa, ok := loadCommandAutomaton(positional[0])
+	if !ok {
+		return exitError
+	}
+
+	prefix, result := fa.LongestPrefix(positional[1])
+	found := prefix != ""
+	code := exitOK
+	if !found {
+		code = exitFailure
+	}
+
+	if _, asJSON := flags["--json"]; asJSON {
+		output := prefixResult{Found: found, Prefix: prefix, Length: len(prefix)}
+		if found {
+			output.Result = &result
+		}
+		if printJSON(output) != exitOK {
+			return exitError
+		}
+		return code
+	}
+
+	if !found {
+		fmt.Println("Nu există niciun prefix acceptat.")
+	} else {
+		fmt.Println(prefix)
+	}
+	return code
+}
+
+// validationResult is the JSON output of the validate command, one per file.
+type validationResult struct {
+	File  string                `json:"file"`
+	Valid bool                  `json:"valid"`
+	Error string                `json:"error,omitempty"`
+	Tests *automaton.TestReport `json:"tests,omitempty"`
+}
+
+// validateCommand checks every file and runs its embedded tests; it exits
+// with 1 if some file is invalid or some test fails.
+func validateCommand(args []string) int {
+	flags, files, ok := commandArgs(args, -1, []string{"--json"}, nil)
+	if !ok {
+		return exitError
+	}
+	_, asJSON := flags["--json"]
+
+	results := []validationResult{}
+	code := exitOK
+	for _, file := range files {
+		result := validationResult{File: file}
+		fa, err := automaton.ParseFromFile(file)
+		if err != nil {
+			result.Error = err.Error()
+			code = exitFailure
+		} else {
+			result.Valid = true
+			if len(fa.Tests) > 0 {
+				report := fa.RunEmbeddedTests()
+				result.Tests = &report
+				if len(report.Failures) > 0 {
+					code = exitFailure
+				}
+			}
+		}
+		results = append(results, result)
+
+		if asJSON {
+			continue
+		}
+		switch {
+		case !result.Valid:
+			fmt.Printf("%s: invalid: %s\n", file, result.Error)
+		case result.Tests == nil:
+			fmt.Printf("%s: valid\n", file)
+		default:
+			fmt.Printf("%s: valid; %s", file, result.Tests.String())
+		}
+	}
+
+	if asJSON && printJSON(results) != exitOK {
+		return exitError
+	}
+	return code
+}
+
+func convertCommand(args []string) int {
+	flags, files, ok := commandArgs(args, 1, nil, []string{"--to", "-o"})
+	if !ok {
+		return exitError
+	}
+	fa, ok := loadCommandAutomaton(files[0])
+	if !ok {
+		return exitError
+	}
+
+	var output string
+	switch flags["--to"] {
+	case "dot":
+		output = fa.ToDOT()
+	case "json":
+		jsonStr, err := fa.ToJSON()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Eroare: %v\n", err)
+			return exitError
+		}
+		output = jsonStr + "\n"
+	default:
+		fmt.Fprintf(os.Stderr, "Formatul '%s' nu este cunoscut (se așteaptă --to dot sau --to json)\n", flags["--to"])
+		return exitError
+	}
+
+	if path, toFile := flags["-o"]; toFile {
+		if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Eroare: %v\n", err)
+			return exitError
+		}
+		return exitOK
+	}
+	fmt.Print(output)
+	return exitOK
+}
@@ -0,0 +1,57 @@
+//go:build !wasm
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+// traceConstruction runs a construction on the current automaton, prints
+// every step and returns the result, which becomes the current automaton.
+func traceConstruction(fa *automaton.FiniteAutomaton, scanner *bufio.Scanner) *automaton.FiniteAutomaton {
+	fmt.Println("\nConstrucții:")
+	fmt.Println("  1. Construcția submulțimilor (AFND → AFD)")
+	fmt.Println("  2. Minimizare (rafinarea partițiilor)")
+	fmt.Println("  3. Eliminarea ε-tranzițiilor")
+	fmt.Println("  4. Construcția produs (intersecție cu L2)")
+	fmt.Print("Alegeți construcția: ")
+	if !scanner.Scan() {
+		return nil
+	}
+
+	var result *automaton.FiniteAutomaton
+	var trace *automaton.ConstructionTrace
+	var err error
+	switch strings.TrimSpace(scanner.Text()) {
+	case "1":
+		result, trace = automaton.DeterminizeWithTrace(fa)
+	case "2":
+		result, trace, err = automaton.MinimizeWithTrace(fa)
+	case "3":
+		result, trace = fa.RemoveEpsilonWithTrace()
+	case "4":
+		other := loadSecondAutomaton(scanner)
+		if other == nil {
+			return nil
+		}
+		result, trace = automaton.IntersectionWithTrace(fa, other)
+	default:
+		fmt.Print("\nOpțiune invalidă!\n\n")
+		return nil
+	}
+
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Println()
+	fmt.Print(trace.String())
+	fmt.Println("\nRezultat (devine automatul curent):")
+	fmt.Println(result.String())
+	return result
+}
@@ -1,3 +1,7 @@
 module core
 
 go 1.25.1
+
+require github.com/bujor/compilers/shared/automaton v0.0.0
+
+replace github.com/bujor/compilers/shared/automaton => ../../shared/automaton
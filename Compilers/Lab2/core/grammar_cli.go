@@ -0,0 +1,64 @@
+//go:build !wasm
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func loadGrammar(scanner *bufio.Scanner) *automaton.FiniteAutomaton {
+	fmt.Print("\nIntroduceți calea către fișierul gramaticii: ")
+	if !scanner.Scan() {
+		return nil
+	}
+
+	filename := strings.TrimSpace(scanner.Text())
+	g, err := automaton.ParseGrammarFromFile(filename)
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Println("\n=== Gramatică ===")
+	fmt.Printf("Tip: %s\n", g.TypeString())
+	fmt.Printf("Neterminale: {%s}\n", strings.Join(g.Nonterminals, ", "))
+	fmt.Printf("Terminale: {%s}\n", strings.Join(g.Terminals, ", "))
+	fmt.Printf("Simbol de start: %s\n\n", g.Start)
+	fmt.Print(g.String())
+
+	fa, err := automaton.GrammarToFA(g)
+	if err != nil {
+		fmt.Printf("\nEroare la conversie: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Println("\nAutomat obținut (devine automatul curent):")
+	fmt.Println(fa.String())
+
+	return fa
+}
+
+func displayGrammar(fa *automaton.FiniteAutomaton, scanner *bufio.Scanner) {
+	g := automaton.FAToGrammar(fa)
+
+	fmt.Println("\n=== Gramatică Liniară la Dreapta ===")
+	fmt.Printf("Simbol de start: %s\n\n", g.Start)
+	fmt.Print(g.String())
+
+	fmt.Print("\nIntroduceți calea pentru salvare (gol pentru a nu salva): ")
+	if scanner.Scan() {
+		if filename := strings.TrimSpace(scanner.Text()); filename != "" {
+			if err := os.WriteFile(filename, []byte(g.String()), 0644); err != nil {
+				fmt.Printf("\nEroare la scrierea fișierului: %v\n", err)
+			} else {
+				fmt.Printf("\nGramatică salvată în %s\n", filename)
+			}
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,141 @@
+//go:build !wasm
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func learnFromExamples(scanner *bufio.Scanner) *automaton.FiniteAutomaton {
+	fmt.Print("\nIntroduceți calea către fișierul cu exemple pozitive: ")
+	if !scanner.Scan() {
+		return nil
+	}
+	positive, err := readWordFile(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Print("Introduceți calea către fișierul cu exemple negative: ")
+	if !scanner.Scan() {
+		return nil
+	}
+	negative, err := readWordFile(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fa, err := automaton.RPNI(positive, negative)
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Printf("\nAutomat învățat din %d exemple pozitive și %d negative (devine automatul curent):\n",
+		len(positive), len(negative))
+	fmt.Println(fa.String())
+
+	fmt.Print("Introduceți calea pentru salvare (gol pentru a nu salva): ")
+	if scanner.Scan() {
+		if filename := strings.TrimSpace(scanner.Text()); filename != "" {
+			if err := fa.SaveToFile(filename); err != nil {
+				fmt.Printf("\nEroare: %v\n", err)
+			} else {
+				fmt.Printf("\nAutomat salvat în %s\n", filename)
+			}
+		}
+	}
+	fmt.Println()
+
+	return fa
+}
+
+// readWordFile reads one word per line. Blank lines are skipped and a line
+// containing only ε stands for the empty word.
+func readWordFile(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+
+	words := []string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		word := strings.TrimSpace(line)
+		if word == "" {
+			continue
+		}
+		if word == automaton.Epsilon {
+			word = ""
+		}
+		words = append(words, word)
+	}
+
+	return words, nil
+}
+
+// learnWithLStar treats the current automaton as a black box: membership
+// queries simulate it and the equivalence oracle is either the automaton
+// itself or random testing.
+func learnWithLStar(target *automaton.FiniteAutomaton, scanner *bufio.Scanner) *automaton.FiniteAutomaton {
+	membership := func(word string) bool {
+		return target.Simulate(word).Accepted
+	}
+
+	fmt.Print("\nOracol de echivalență (1 = automatul de referință, 2 = testare aleatoare): ")
+	if !scanner.Scan() {
+		return nil
+	}
+
+	var oracle automaton.EquivalenceOracle
+	switch strings.TrimSpace(scanner.Text()) {
+	case "1":
+		oracle = &automaton.ReferenceOracle{Target: target}
+	case "2":
+		random, err := automaton.NewRandomTestingOracle(membership, target.Alphabet, 1000, 2*len(target.States))
+		if err != nil {
+			fmt.Printf("\nEroare: %v\n\n", err)
+			return nil
+		}
+		oracle = random
+	default:
+		fmt.Print("\nOpțiune invalidă!\n\n")
+		return nil
+	}
+
+	result, err := automaton.LStar(target.Alphabet, membership, oracle)
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Println("\nIstoricul învățării:")
+	for i, round := range result.History {
+		fmt.Printf("  Ipoteza %d: %d stări, tabel %d×%d", i+1, round.HypothesisStates,
+			len(round.Table.Prefixes)+len(round.Table.Boundary), len(round.Table.Suffixes))
+		if round.Correct {
+			fmt.Println(" → acceptată")
+		} else {
+			fmt.Printf(" → contraexemplu '%s'\n", displayWord(round.Counterexample))
+		}
+	}
+
+	fmt.Printf("\nAutomat învățat cu %d interogări de apartenență (devine automatul curent):\n",
+		result.MembershipQueries)
+	fmt.Println(result.Automaton.String())
+
+	return result.Automaton
+}
+
+func displayWord(word string) string {
+	if word == "" {
+		return automaton.Epsilon
+	}
+	return word
+}
@@ -7,11 +7,20 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
 	scanner := bufio.NewScanner(os.Stdin)
-	var fa *FiniteAutomaton
+	var fa *automaton.FiniteAutomaton
+	var tr automaton.Transducer
+	var pda *automaton.PushdownAutomaton
+	var tm *automaton.TuringMachine
 
 	fmt.Println("╔════════════════════════════════════════════════════╗")
 	fmt.Println("║     Simulator Automate Finite                      ║")
@@ -35,51 +44,130 @@ func main() {
 			fa = createManually(scanner)
 		case "3":
 			if fa == nil {
-				fmt.Println("\nNu există automat încărcat! Încărcați mai întâi un automat.\n")
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
 			} else {
 				displayStates(fa)
 			}
 		case "4":
 			if fa == nil {
-				fmt.Println("\nNu există automat încărcat! Încărcați mai întâi un automat.\n")
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
 			} else {
 				displayAlphabet(fa)
 			}
 		case "5":
 			if fa == nil {
-				fmt.Println("\nNu există automat încărcat! Încărcați mai întâi un automat.\n")
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
 			} else {
 				displayTransitions(fa)
 			}
 		case "6":
 			if fa == nil {
-				fmt.Println("\nNu există automat încărcat! Încărcați mai întâi un automat.\n")
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
 			} else {
 				displayFinalStates(fa)
 			}
 		case "7":
 			if fa == nil {
-				fmt.Println("\nNu există automat încărcat! Încărcați mai întâi un automat.\n")
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
 			} else {
 				checkSequence(fa, scanner)
 			}
 		case "8":
 			if fa == nil {
-				fmt.Println("\nNu există automat încărcat! Încărcați mai întâi un automat.\n")
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
 			} else {
 				findLongestPrefix(fa, scanner)
 			}
 		case "9":
 			if fa != nil {
 				fmt.Println(fa.String())
+				displayAnalysis(fa)
+			} else {
+				fmt.Print("\nNu există automat încărcat!\n\n")
+			}
+		case "10":
+			tr = loadTransducer(scanner)
+		case "11":
+			if tr == nil {
+				fmt.Print("\nNu există traductor încărcat! Încărcați mai întâi un traductor.\n\n")
 			} else {
-				fmt.Println("\nNu există automat încărcat!\n")
+				translateSequence(tr, scanner)
+			}
+		case "12":
+			if tr == nil {
+				fmt.Print("\nNu există traductor încărcat! Încărcați mai întâi un traductor.\n\n")
+			} else {
+				tr = convertTransducer(tr, scanner)
+			}
+		case "13":
+			pda = loadPushdown(scanner)
+		case "14":
+			if pda == nil {
+				fmt.Print("\nNu există automat push-down încărcat! Încărcați mai întâi un automat.\n\n")
+			} else {
+				checkPushdownSequence(pda, scanner)
+			}
+		case "15":
+			tm = loadTuring(scanner)
+		case "16":
+			if tm == nil {
+				fmt.Print("\nNu există mașină Turing încărcată! Încărcați mai întâi o mașină.\n\n")
+			} else {
+				runTuring(tm, scanner)
+			}
+		case "17":
+			if converted := loadGrammar(scanner); converted != nil {
+				fa = converted
+			}
+		case "18":
+			if fa == nil {
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
+			} else {
+				displayGrammar(fa, scanner)
+			}
+		case "19":
+			if learned := learnFromExamples(scanner); learned != nil {
+				fa = learned
+			}
+		case "20":
+			if fa == nil {
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
+			} else if learned := learnWithLStar(fa, scanner); learned != nil {
+				fa = learned
+			}
+		case "21":
+			if fa == nil {
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
+			} else {
+				runEmbeddedTests(fa)
+			}
+		case "22":
+			if generated := generateExercise(scanner); generated != nil {
+				fa = generated
+			}
+		case "23":
+			if fa == nil {
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
+			} else if result := applyOperation(fa, scanner); result != nil {
+				fa = result
+			}
+		case "24":
+			if fa == nil {
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
+			} else if result := traceConstruction(fa, scanner); result != nil {
+				fa = result
+			}
+		case "25":
+			if fa == nil {
+				fmt.Print("\nNu există automat încărcat! Încărcați mai întâi un automat.\n\n")
+			} else {
+				displayTableFilling(fa)
 			}
 		case "0":
 			fmt.Println("\nLa revedere!")
 			return
 		default:
-			fmt.Println("\nOpțiune invalidă! Încercați din nou.\n")
+			fmt.Print("\nOpțiune invalidă! Încercați din nou.\n\n")
 		}
 	}
 }
@@ -97,19 +185,35 @@ func printMenu() {
 	fmt.Println("║  7. Verifică secvență                              ║")
 	fmt.Println("║  8. Găsește cel mai lung prefix acceptat           ║")
 	fmt.Println("║  9. Afișează automatul complet                     ║")
+	fmt.Println("║  10. Încarcă traductor (Mealy/Moore)               ║")
+	fmt.Println("║  11. Traduce secvență                              ║")
+	fmt.Println("║  12. Convertește traductorul (Mealy ⇄ Moore)       ║")
+	fmt.Println("║  13. Încarcă automat push-down                     ║")
+	fmt.Println("║  14. Verifică secvență (automat push-down)         ║")
+	fmt.Println("║  15. Încarcă mașină Turing                         ║")
+	fmt.Println("║  16. Rulează mașina Turing                         ║")
+	fmt.Println("║  17. Încarcă gramatică regulară (→ automat)        ║")
+	fmt.Println("║  18. Convertește automatul în gramatică            ║")
+	fmt.Println("║  19. Învață automat din exemple (RPNI)             ║")
+	fmt.Println("║  20. Învață automatul curent (L*)                  ║")
+	fmt.Println("║  21. Rulează testele încorporate                   ║")
+	fmt.Println("║  22. Generează automat aleator (exercițiu)         ║")
+	fmt.Println("║  23. Operații pe limbaje (câturi, închideri, ...)  ║")
+	fmt.Println("║  24. Construcții pas cu pas (AFD, minimizare)      ║")
+	fmt.Println("║  25. Tabelul de distingere (Myhill–Nerode)         ║")
 	fmt.Println("║  0. Ieșire                                         ║")
 	fmt.Println("╚════════════════════════════════════════════════════╝")
 	fmt.Println()
 }
 
-func loadFromFile(scanner *bufio.Scanner) *FiniteAutomaton {
+func loadFromFile(scanner *bufio.Scanner) *automaton.FiniteAutomaton {
 	fmt.Print("\nIntroduceți calea către fișierul JSON: ")
 	if !scanner.Scan() {
 		return nil
 	}
 
 	filename := strings.TrimSpace(scanner.Text())
-	fa, err := ParseFromFile(filename)
+	fa, err := automaton.ParseFromFile(filename)
 
 	if err != nil {
 		fmt.Printf("\nEroare: %v\n\n", err)
@@ -117,13 +221,13 @@ func loadFromFile(scanner *bufio.Scanner) *FiniteAutomaton {
 	}
 
 	fmt.Println("\nAutomat încărcat cu succes!")
-	fmt.Printf("Tip: %s\n", fa.typeString())
+	fmt.Printf("Tip: %s\n", fa.TypeString())
 	fmt.Printf("Stări: %d, Alfabet: %d simboluri\n\n", len(fa.States), len(fa.Alphabet))
 
 	return fa
 }
 
-func createManually(scanner *bufio.Scanner) *FiniteAutomaton {
+func createManually(scanner *bufio.Scanner) *automaton.FiniteAutomaton {
 	fmt.Println("\n=== Creare Automat Manual ===")
 
 	fmt.Print("Introduceți stările (separate prin virgulă): ")
@@ -179,7 +283,7 @@ func createManually(scanner *bufio.Scanner) *FiniteAutomaton {
 		transitions[from][symbol] = append(transitions[from][symbol], to)
 	}
 
-	fa := &FiniteAutomaton{
+	fa := &automaton.FiniteAutomaton{
 		States:       states,
 		Alphabet:     alphabet,
 		Transitions:  transitions,
@@ -193,51 +297,46 @@ func createManually(scanner *bufio.Scanner) *FiniteAutomaton {
 	}
 
 	fmt.Println("\nAutomat creat cu succes!")
-	fmt.Printf("Tip: %s\n\n", fa.typeString())
+	fmt.Printf("Tip: %s\n\n", fa.TypeString())
 
 	return fa
 }
 
-func displayStates(fa *FiniteAutomaton) {
+func displayStates(fa *automaton.FiniteAutomaton) {
 	fmt.Println("\n=== Stări ===")
 	fmt.Printf("Stări: {%s}\n", strings.Join(fa.States, ", "))
 	fmt.Printf("Total: %d stări\n\n", len(fa.States))
 }
 
-func displayAlphabet(fa *FiniteAutomaton) {
+func displayAlphabet(fa *automaton.FiniteAutomaton) {
 	fmt.Println("\n=== Alfabet ===")
 	fmt.Printf("Alfabet: {%s}\n", strings.Join(fa.Alphabet, ", "))
 	fmt.Printf("Total: %d simboluri\n\n", len(fa.Alphabet))
 }
 
-func displayTransitions(fa *FiniteAutomaton) {
+func displayTransitions(fa *automaton.FiniteAutomaton) {
 	fmt.Println("\n=== Tranziții ===")
-	count := 0
-	for state, transitions := range fa.Transitions {
-		for symbol, nextStates := range transitions {
-			for _, nextState := range nextStates {
-				fmt.Printf("  %s --%s--> %s\n", state, symbol, nextState)
-				count++
-			}
-		}
+	transitions := fa.TransitionList()
+	for _, t := range transitions {
+		fmt.Printf("  %s --%s--> %s\n", t.From, fa.TransitionLabel(t), t.To)
 	}
-	fmt.Printf("Total: %d tranziții\n\n", count)
+	fmt.Printf("Total: %d tranziții\n\n", len(transitions))
 }
 
-func displayFinalStates(fa *FiniteAutomaton) {
+func displayFinalStates(fa *automaton.FiniteAutomaton) {
 	fmt.Println("\n=== Stări Finale ===")
 	fmt.Printf("Stări finale: {%s}\n", strings.Join(fa.FinalStates, ", "))
 	fmt.Printf("Total: %d stări finale\n\n", len(fa.FinalStates))
 }
 
-func checkSequence(fa *FiniteAutomaton, scanner *bufio.Scanner) {
+func checkSequence(fa *automaton.FiniteAutomaton, scanner *bufio.Scanner) {
 	fmt.Print("\nIntroduceți secvența de verificat: ")
 	if !scanner.Scan() {
 		return
 	}
 
 	sequence := strings.TrimSpace(scanner.Text())
-	result := fa.Simulate(sequence)
+	result := fa.SimulateWithRepair(sequence)
 
 	fmt.Println("\n=== Rezultat Simulare ===")
 
@@ -251,6 +350,10 @@ func checkSequence(fa *FiniteAutomaton, scanner *bufio.Scanner) {
 	fmt.Printf("\nPași efectuați: %d\n", len(result.Steps))
 	fmt.Printf("Stări finale: {%s}\n\n", strings.Join(result.FinalStates, ", "))
 
+	if result.Weight != nil {
+		displayWeight(fa, sequence, *result.Weight)
+	}
+
 	fmt.Print("Doriți să vedeți pașii detaliat? (da/nu): ")
 	if scanner.Scan() && strings.ToLower(strings.TrimSpace(scanner.Text())) == "da" {
 		displaySteps(result.Steps, sequence)
@@ -258,7 +361,7 @@ func checkSequence(fa *FiniteAutomaton, scanner *bufio.Scanner) {
 	fmt.Println()
 }
 
-func findLongestPrefix(fa *FiniteAutomaton, scanner *bufio.Scanner) {
+func findLongestPrefix(fa *automaton.FiniteAutomaton, scanner *bufio.Scanner) {
 	fmt.Print("\nIntroduceți secvența: ")
 	if !scanner.Scan() {
 		return
@@ -279,7 +382,7 @@ func findLongestPrefix(fa *FiniteAutomaton, scanner *bufio.Scanner) {
 	fmt.Println()
 }
 
-func displayError(err *SimulationError) {
+func displayError(err *automaton.SimulationError) {
 	switch err.Type {
 	case "invalid_char":
 		fmt.Println("CARACTER INVALID")
@@ -287,6 +390,10 @@ func displayError(err *SimulationError) {
 		fmt.Println("TRANZIȚIE LIPSĂ")
 	case "not_final":
 		fmt.Println("RESPINS")
+	case "depth_limit":
+		fmt.Println("LIMITĂ DE ADÂNCIME ATINSĂ")
+	case "step_limit":
+		fmt.Println("LIMITĂ DE PAȘI ATINSĂ")
 	}
 
 	fmt.Printf("%s\n", err.Message)
@@ -297,9 +404,13 @@ func displayError(err *SimulationError) {
 	if err.Symbol != "" {
 		fmt.Printf("Simbol: '%s'\n", err.Symbol)
 	}
+	if err.Suggestion != nil {
+		fmt.Println()
+		fmt.Print(err.Suggestion.String())
+	}
 }
 
-func displaySteps(steps []Step, sequence string) {
+func displaySteps(steps []automaton.Step, sequence string) {
 	fmt.Println("\n=== Pași Detaliat ===")
 	fmt.Printf("Stare inițială → (start)\n")
 
@@ -307,6 +418,15 @@ func displaySteps(steps []Step, sequence string) {
 		fmt.Printf("\nPasul %d:\n", i+1)
 		fmt.Printf("  Caracter: '%s' (poziția %d)\n", step.Symbol, step.CharIndex)
 		fmt.Printf("  Stări active: {%s}\n", strings.Join(step.ActiveStates, ", "))
+		if step.Output != "" {
+			fmt.Printf("  Ieșire: '%s'\n", step.Output)
+		}
+		if step.Stack != nil {
+			fmt.Printf("  Stivă (vârf primul): %s\n", formatStack(step.Stack))
+		}
+		for j, tape := range step.Tape {
+			fmt.Printf("  Banda %d: %s\n", j+1, formatTape(tape))
+		}
 
 		if len(step.Transitions) > 0 {
 			fmt.Println("  Tranziții:")
@@ -0,0 +1,118 @@
+//go:build !wasm
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+// applyOperation applies a closure operation to the current automaton and
+// returns the result, which becomes the current automaton.
+func applyOperation(fa *automaton.FiniteAutomaton, scanner *bufio.Scanner) *automaton.FiniteAutomaton {
+	fmt.Println("\nOperații:")
+	fmt.Println("  1. Cât la dreapta (L / L2)")
+	fmt.Println("  2. Cât la stânga (L2 \\ L)")
+	fmt.Println("  3. Închiderea la prefixe")
+	fmt.Println("  4. Închiderea la sufixe")
+	fmt.Println("  5. Închiderea la factori")
+	fmt.Println("  6. Omomorfism")
+	fmt.Println("  7. Omomorfism invers")
+	fmt.Println("  8. Produsul shuffle (L ⧢ L2)")
+	fmt.Print("Alegeți operația: ")
+	if !scanner.Scan() {
+		return nil
+	}
+
+	choice := strings.TrimSpace(scanner.Text())
+
+	var result *automaton.FiniteAutomaton
+	var err error
+	switch choice {
+	case "1", "2", "8":
+		other := loadSecondAutomaton(scanner)
+		if other == nil {
+			return nil
+		}
+		switch choice {
+		case "1":
+			result = automaton.RightQuotient(fa, other)
+		case "2":
+			result = automaton.LeftQuotient(fa, other)
+		case "8":
+			result = automaton.Shuffle(fa, other)
+		}
+	case "3":
+		result = automaton.PrefixClosure(fa)
+	case "4":
+		result = automaton.SuffixClosure(fa)
+	case "5":
+		result = automaton.InfixClosure(fa)
+	case "6", "7":
+		h, ok := readHomomorphism(scanner)
+		if !ok {
+			return nil
+		}
+		if choice == "7" {
+			result, err = automaton.InverseHomomorphism(fa, h)
+		} else {
+			result, err = automaton.Homomorphism(fa, h)
+		}
+	default:
+		fmt.Print("\nOpțiune invalidă!\n\n")
+		return nil
+	}
+
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Println("\nRezultat (devine automatul curent):")
+	fmt.Println(result.String())
+	return result
+}
+
+func loadSecondAutomaton(scanner *bufio.Scanner) *automaton.FiniteAutomaton {
+	fmt.Print("Introduceți calea către al doilea automat (L2): ")
+	if !scanner.Scan() {
+		return nil
+	}
+	other, err := automaton.ParseFromFile(strings.TrimSpace(scanner.Text()))
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+	return other
+}
+
+// readHomomorphism reads a mapping written as "a=xy, b=, c=z"; an empty
+// image maps the symbol to the empty word.
+func readHomomorphism(scanner *bufio.Scanner) (map[string]string, bool) {
+	fmt.Print("Introduceți omomorfismul (ex: a=xy, b=, c=z): ")
+	if !scanner.Scan() {
+		return nil, false
+	}
+
+	h := make(map[string]string)
+	for _, entry := range strings.Split(scanner.Text(), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		symbol, image, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(symbol) == "" {
+			fmt.Printf("\nIntrare invalidă: '%s'\n\n", entry)
+			return nil, false
+		}
+		image = strings.TrimSpace(image)
+		if image == automaton.Epsilon {
+			image = ""
+		}
+		h[strings.TrimSpace(symbol)] = image
+	}
+	return h, true
+}
@@ -0,0 +1,83 @@
+//go:build !wasm
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func loadPushdown(scanner *bufio.Scanner) *automaton.PushdownAutomaton {
+	fmt.Print("\nIntroduceți calea către fișierul JSON al automatului push-down: ")
+	if !scanner.Scan() {
+		return nil
+	}
+
+	filename := strings.TrimSpace(scanner.Text())
+	pda, err := automaton.ParsePushdownFromFile(filename)
+
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Println("\nAutomat push-down încărcat cu succes!")
+	fmt.Printf("Tip: %s\n", pda.TypeString())
+	fmt.Printf("Stări: %d, Alfabet: %d simboluri, Alfabetul stivei: %d simboluri\n\n",
+		len(pda.States), len(pda.Alphabet), len(pda.StackAlphabet))
+
+	return pda
+}
+
+func checkPushdownSequence(pda *automaton.PushdownAutomaton, scanner *bufio.Scanner) {
+	fmt.Print("\nIntroduceți secvența de verificat: ")
+	if !scanner.Scan() {
+		return
+	}
+	sequence := strings.TrimSpace(scanner.Text())
+
+	fmt.Printf("Limita de adâncime a căutării (gol pentru %d): ", automaton.DefaultPDADepthLimit)
+	if !scanner.Scan() {
+		return
+	}
+	depthLimit := automaton.DefaultPDADepthLimit
+	if text := strings.TrimSpace(scanner.Text()); text != "" {
+		limit, err := strconv.Atoi(text)
+		if err != nil || limit <= 0 {
+			fmt.Print("\nLimită invalidă! Se folosește valoarea implicită.\n")
+		} else {
+			depthLimit = limit
+		}
+	}
+
+	result := pda.SimulateWithDepthLimit(sequence, depthLimit)
+
+	fmt.Println("\n=== Rezultat Simulare ===")
+
+	if result.Error != nil {
+		displayError(result.Error)
+	} else {
+		fmt.Println("ACCEPTAT")
+		fmt.Printf("Secvența '%s' este acceptată de automat.\n", sequence)
+	}
+
+	fmt.Printf("\nMutări efectuate: %d\n", len(result.Steps))
+	fmt.Printf("Stări finale: {%s}\n\n", strings.Join(result.FinalStates, ", "))
+
+	fmt.Print("Doriți să vedeți pașii detaliat? (da/nu): ")
+	if scanner.Scan() && strings.ToLower(strings.TrimSpace(scanner.Text())) == "da" {
+		displaySteps(result.Steps, sequence)
+	}
+	fmt.Println()
+}
+
+func formatStack(stack []string) string {
+	if len(stack) == 0 {
+		return automaton.Epsilon
+	}
+	return strings.Join(stack, "")
+}
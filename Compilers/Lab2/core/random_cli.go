@@ -0,0 +1,171 @@
+//go:build !wasm
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+// exerciseWords is the number of accepted and of rejected words in an exercise.
+const exerciseWords = 5
+
+// generateExercise creates a random automaton and prints words to classify,
+// followed by the answer key.
+func generateExercise(scanner *bufio.Scanner) *automaton.FiniteAutomaton {
+	deterministic := true
+	fmt.Print("\nTipul automatului (1 = AFD, 2 = AFND): ")
+	if !scanner.Scan() {
+		return nil
+	}
+	switch strings.TrimSpace(scanner.Text()) {
+	case "1":
+	case "2":
+		deterministic = false
+	default:
+		fmt.Print("\nOpțiune invalidă!\n\n")
+		return nil
+	}
+
+	opts := automaton.RandomOptions{Connected: true}
+	var ok bool
+	if opts.States, ok = readInt(scanner, "Numărul de stări", 5); !ok {
+		return nil
+	}
+	if opts.Alphabet, ok = readInt(scanner, "Dimensiunea alfabetului", 2); !ok {
+		return nil
+	}
+	defaultDensity := 0.8
+	if !deterministic {
+		defaultDensity = 0.3
+	}
+	if opts.Density, ok = readFloat(scanner, "Densitatea tranzițiilor (0-1)", defaultDensity); !ok {
+		return nil
+	}
+	if opts.Acceptance, ok = readFloat(scanner, "Proporția stărilor finale (0-1)", 0.4); !ok {
+		return nil
+	}
+	seed, ok := readInt(scanner, "Sămânța", int(time.Now().UnixNano()%1000000))
+	if !ok {
+		return nil
+	}
+	opts.Rand = rand.New(rand.NewSource(int64(seed)))
+
+	var fa *automaton.FiniteAutomaton
+	var err error
+	if deterministic {
+		fa, err = automaton.RandomDFA(opts)
+	} else {
+		fa, err = automaton.RandomNFA(opts)
+	}
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Printf("\nAutomat generat cu sămânța %d (devine automatul curent):\n", seed)
+	fmt.Println(fa.String())
+
+	words := exerciseWordList(fa, opts.Rand)
+	if len(words) == 0 {
+		fmt.Print("Nu s-au putut genera cuvinte pentru exercițiu.\n\n")
+		return fa
+	}
+
+	fmt.Println("Exercițiu: care dintre cuvintele următoare sunt acceptate?")
+	for i, word := range words {
+		fmt.Printf("  %2d. %s\n", i+1, displayWord(word))
+	}
+
+	fmt.Println("\nRăspunsuri:")
+	for i, word := range words {
+		verdict := "respins"
+		if fa.Simulate(word).Accepted {
+			verdict = "acceptat"
+		}
+		fmt.Printf("  %2d. %s → %s\n", i+1, displayWord(word), verdict)
+	}
+	fmt.Println()
+
+	return fa
+}
+
+// exerciseWordList draws words from the language and from its complement,
+// uniformly among words of length at most twice the number of states. For
+// an NFA the words are uniformly random over the alphabet.
+func exerciseWordList(fa *automaton.FiniteAutomaton, random *rand.Rand) []string {
+	maxLength := 2 * len(fa.States)
+	seen := make(map[string]bool)
+	words := []string{}
+	add := func(word string) {
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+
+	if fa.IsDeterministic() {
+		for _, complement := range []bool{false, true} {
+			sampler, err := automaton.NewWordSampler(fa, maxLength, complement)
+			if err != nil {
+				return nil
+			}
+			for i := 0; i < exerciseWords; i++ {
+				if word, found := sampler.SampleUpTo(maxLength, random); found {
+					add(word)
+				}
+			}
+		}
+	} else {
+		for i := 0; i < 2*exerciseWords; i++ {
+			var sb strings.Builder
+			for j := random.Intn(maxLength + 1); j > 0; j-- {
+				sb.WriteString(fa.Alphabet[random.Intn(len(fa.Alphabet))])
+			}
+			add(sb.String())
+		}
+	}
+
+	random.Shuffle(len(words), func(i, j int) { words[i], words[j] = words[j], words[i] })
+	return words
+}
+
+func readInt(scanner *bufio.Scanner, prompt string, fallback int) (int, bool) {
+	fmt.Printf("%s (gol pentru %d): ", prompt, fallback)
+	if !scanner.Scan() {
+		return 0, false
+	}
+	text := strings.TrimSpace(scanner.Text())
+	if text == "" {
+		return fallback, true
+	}
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		fmt.Print("\nValoare invalidă!\n\n")
+		return 0, false
+	}
+	return value, true
+}
+
+func readFloat(scanner *bufio.Scanner, prompt string, fallback float64) (float64, bool) {
+	fmt.Printf("%s (gol pentru %g): ", prompt, fallback)
+	if !scanner.Scan() {
+		return 0, false
+	}
+	text := strings.TrimSpace(scanner.Text())
+	if text == "" {
+		return fallback, true
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		fmt.Print("\nValoare invalidă!\n\n")
+		return 0, false
+	}
+	return value, true
+}
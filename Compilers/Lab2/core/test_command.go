@@ -0,0 +1,302 @@
+//go:build !wasm
+
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+// caseResult is the outcome of one test case on one automaton.
+type caseResult struct {
+	Test     automaton.EmbeddedTest
+	Result   automaton.SimulationResult
+	Passed   bool
+	Duration time.Duration
+}
+
+// suiteResult holds the results of every case on one automaton file.
+type suiteResult struct {
+	File     string
+	Name     string
+	Cases    []caseResult
+	Failures int
+	Err      error // the automaton could not be loaded
+	Duration time.Duration
+}
+
+// testCommand runs the cases file against one or more automata, so a whole
+// group of submissions can be graded with the same cases. It exits with 0
+// when every case passes, 1 when some case fails or an automaton is invalid,
+// and 2 when the cases file is unusable.
+func testCommand(args []string) int {
+	flags, files, ok := commandArgs(args, -1, nil, []string{"--junit"})
+	if !ok {
+		return exitError
+	}
+	if len(files) < 2 {
+		printUsage()
+		return exitError
+	}
+	junit := flags["--junit"]
+
+	casesFile := files[len(files)-1]
+	cases, err := readCasesFile(casesFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Eroare (%s): %v\n", casesFile, err)
+		return exitError
+	}
+
+	suites := []suiteResult{}
+	failed := false
+	for _, file := range files[:len(files)-1] {
+		suite := runSuite(file, cases)
+		printSuite(suite)
+		if suite.Err != nil || suite.Failures > 0 {
+			failed = true
+		}
+		suites = append(suites, suite)
+	}
+
+	if junit != "" {
+		if err := writeJUnit(junit, suites); err != nil {
+			fmt.Fprintf(os.Stderr, "Eroare (%s): %v\n", junit, err)
+			return exitError
+		}
+	}
+
+	if failed {
+		return exitFailure
+	}
+	return exitOK
+}
+
+// readCasesFile reads one case per line: a word and the expected verdict,
+// accept or reject. A line with only the verdict, or with ε as the word,
+// tests the empty word; blank lines and lines starting with # are skipped.
+func readCasesFile(filename string) ([]automaton.EmbeddedTest, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+
+	cases := []automaton.EmbeddedTest{}
+	for i, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		word, expect := "", fields[len(fields)-1]
+		switch len(fields) {
+		case 1:
+		case 2:
+			word = fields[0]
+		default:
+			return nil, fmt.Errorf("linia %d: se așteaptă un cuvânt și %s sau %s", i+1, automaton.ExpectAccept, automaton.ExpectReject)
+		}
+		if word == automaton.Epsilon {
+			word = ""
+		}
+		if expect != automaton.ExpectAccept && expect != automaton.ExpectReject {
+			return nil, fmt.Errorf("linia %d: rezultatul așteptat '%s' nu este %s sau %s", i+1, expect, automaton.ExpectAccept, automaton.ExpectReject)
+		}
+		cases = append(cases, automaton.EmbeddedTest{Input: word, Expect: expect})
+	}
+
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("fișierul nu conține niciun caz de test")
+	}
+	return cases, nil
+}
+
+// runSuite simulates the cases on one worker per CPU. The automaton is only
+// read by Simulate, so the workers can share it.
+func runSuite(file string, cases []automaton.EmbeddedTest) suiteResult {
+	suite := suiteResult{File: file, Name: file}
+	start := time.Now()
+
+	fa, err := automaton.ParseFromFile(file)
+	if err != nil {
+		suite.Err = err
+		return suite
+	}
+	if fa.Name != "" {
+		suite.Name = fa.Name
+	}
+
+	suite.Cases = make([]caseResult, len(cases))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(runtime.NumCPU(), len(cases)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				caseStart := time.Now()
+				result := fa.Simulate(cases[i].Input)
+				suite.Cases[i] = caseResult{
+					Test:     cases[i],
+					Result:   result,
+					Passed:   result.Accepted == (cases[i].Expect == automaton.ExpectAccept),
+					Duration: time.Since(caseStart),
+				}
+			}
+		}()
+	}
+	for i := range cases {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, c := range suite.Cases {
+		if !c.Passed {
+			suite.Failures++
+		}
+	}
+	suite.Duration = time.Since(start)
+	return suite
+}
+
+// failureMessage explains a failed case, with the SimulationError when the
+// word was rejected.
+func failureMessage(c caseResult) string {
+	if c.Result.Accepted {
+		return "acceptat, se aștepta respingerea"
+	}
+	if c.Result.Error != nil {
+		return "respins, se aștepta acceptarea: " + c.Result.Error.Message
+	}
+	return "respins, se aștepta acceptarea"
+}
+
+func verdict(accepted bool) string {
+	if accepted {
+		return automaton.ExpectAccept
+	}
+	return automaton.ExpectReject
+}
+
+func printSuite(suite suiteResult) {
+	fmt.Printf("=== %s ===\n", suite.File)
+	if suite.Err != nil {
+		fmt.Printf("Eroare: %v\n\n", suite.Err)
+		return
+	}
+
+	width := utf8.RuneCountInString("Cuvânt")
+	for _, c := range suite.Cases {
+		width = max(width, utf8.RuneCountInString(displayWord(c.Test.Input)))
+	}
+	pad := func(s string) string {
+		return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+	}
+
+	fmt.Printf("  %4s  %s  %-8s %-8s %s\n", "#", pad("Cuvânt"), "Așteptat", "Obținut", "Rezultat")
+	for i, c := range suite.Cases {
+		status := "✓"
+		if !c.Passed {
+			status = "✗ " + failureMessage(c)
+		}
+		fmt.Printf("  %4d  %s  %-8s %-8s %s\n", i+1, pad(displayWord(c.Test.Input)), c.Test.Expect, verdict(c.Result.Accepted), status)
+	}
+
+	fmt.Printf("\nTeste: %d/%d trecute", len(suite.Cases)-suite.Failures, len(suite.Cases))
+	if suite.Failures > 0 {
+		fmt.Printf(", %d eșuate", suite.Failures)
+	}
+	fmt.Printf(" (%v)\n\n", suite.Duration.Round(time.Microsecond))
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	File     string      `xml:"file,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Time     string      `xml:"time,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.6f", d.Seconds())
+}
+
+// writeJUnit writes one testsuite per automaton; an automaton that could not
+// be loaded is a suite with a single errored case.
+func writeJUnit(filename string, suites []suiteResult) error {
+	report := junitSuites{}
+	for _, suite := range suites {
+		js := junitSuite{Name: suite.Name, File: suite.File, Time: junitTime(suite.Duration)}
+		if suite.Err != nil {
+			js.Tests, js.Errors = 1, 1
+			js.Cases = []junitCase{{
+				Name:      "load",
+				ClassName: suite.File,
+				Time:      junitTime(0),
+				Error:     &junitMessage{Message: suite.Err.Error()},
+			}}
+		}
+		for _, c := range suite.Cases {
+			jc := junitCase{
+				Name:      fmt.Sprintf("%s %s", displayWord(c.Test.Input), c.Test.Expect),
+				ClassName: suite.File,
+				Time:      junitTime(c.Duration),
+			}
+			if !c.Passed {
+				jc.Failure = &junitMessage{Message: failureMessage(c)}
+				if c.Result.Error != nil {
+					jc.Failure.Type = c.Result.Error.Type
+				}
+			}
+			js.Tests++
+			js.Cases = append(js.Cases, jc)
+		}
+		js.Failures = suite.Failures
+
+		report.Tests += js.Tests
+		report.Failures += js.Failures
+		report.Errors += js.Errors
+		report.Suites = append(report.Suites, js)
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("eroare la serializarea XML: %v", err)
+	}
+	data = append([]byte(xml.Header), append(data, '\n')...)
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("eroare la scrierea fișierului: %v", err)
+	}
+	return nil
+}
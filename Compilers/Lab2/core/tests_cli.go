@@ -0,0 +1,21 @@
+//go:build !wasm
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func runEmbeddedTests(fa *automaton.FiniteAutomaton) {
+	if len(fa.Tests) == 0 {
+		fmt.Print("\nAutomatul nu conține teste (câmpul \"tests\" din fișier).\n\n")
+		return
+	}
+
+	report := fa.RunEmbeddedTests()
+	fmt.Println("\n=== Teste Încorporate ===")
+	fmt.Print(report.String())
+	fmt.Println()
+}
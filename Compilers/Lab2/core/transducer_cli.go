@@ -0,0 +1,89 @@
+//go:build !wasm
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func loadTransducer(scanner *bufio.Scanner) automaton.Transducer {
+	fmt.Print("\nIntroduceți calea către fișierul JSON al traductorului: ")
+	if !scanner.Scan() {
+		return nil
+	}
+
+	filename := strings.TrimSpace(scanner.Text())
+	tr, err := automaton.ParseTransducerFromFile(filename)
+
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Println("\nTraductor încărcat cu succes!")
+	fmt.Printf("Tip: %s\n\n", tr.TypeString())
+
+	return tr
+}
+
+func translateSequence(tr automaton.Transducer, scanner *bufio.Scanner) {
+	fmt.Print("\nIntroduceți secvența de tradus: ")
+	if !scanner.Scan() {
+		return
+	}
+
+	sequence := strings.TrimSpace(scanner.Text())
+	output, result := tr.Simulate(sequence)
+
+	fmt.Println("\n=== Rezultat Traducere ===")
+
+	if result.Error != nil {
+		displayError(result.Error)
+		fmt.Printf("Ieșire parțială: '%s'\n", output)
+	} else {
+		fmt.Printf("Ieșire: '%s'\n", output)
+	}
+
+	fmt.Printf("\nPași efectuați: %d\n", len(result.Steps))
+	fmt.Printf("Stare curentă: {%s}\n\n", strings.Join(result.FinalStates, ", "))
+
+	fmt.Print("Doriți să vedeți pașii detaliat? (da/nu): ")
+	if scanner.Scan() && strings.ToLower(strings.TrimSpace(scanner.Text())) == "da" {
+		displaySteps(result.Steps, sequence)
+	}
+	fmt.Println()
+}
+
+func convertTransducer(tr automaton.Transducer, scanner *bufio.Scanner) automaton.Transducer {
+	var converted automaton.Transducer
+	switch t := tr.(type) {
+	case *automaton.MealyMachine:
+		converted = t.ToMoore()
+	case *automaton.MooreMachine:
+		converted = t.ToMealy()
+		fmt.Println("\nAtenție: ieșirea stării inițiale Moore nu apare în traducerile Mealy.")
+	default:
+		return tr
+	}
+
+	fmt.Println()
+	fmt.Println(converted.String())
+
+	fmt.Print("Introduceți calea pentru salvare (gol pentru a nu salva): ")
+	if scanner.Scan() {
+		if filename := strings.TrimSpace(scanner.Text()); filename != "" {
+			if err := automaton.SaveTransducerToFile(converted, filename); err != nil {
+				fmt.Printf("\nEroare: %v\n", err)
+			} else {
+				fmt.Printf("\nTraductor salvat în %s\n", filename)
+			}
+		}
+	}
+	fmt.Println()
+
+	return converted
+}
@@ -0,0 +1,93 @@
+//go:build !wasm
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func loadTuring(scanner *bufio.Scanner) *automaton.TuringMachine {
+	fmt.Print("\nIntroduceți calea către fișierul JSON al mașinii Turing: ")
+	if !scanner.Scan() {
+		return nil
+	}
+
+	filename := strings.TrimSpace(scanner.Text())
+	tm, err := automaton.ParseTuringFromFile(filename)
+
+	if err != nil {
+		fmt.Printf("\nEroare: %v\n\n", err)
+		return nil
+	}
+
+	fmt.Println("\nMașină Turing încărcată cu succes!")
+	fmt.Printf("Tip: %s\n", tm.TypeString())
+	fmt.Printf("Stări: %d, Alfabetul benzii: %d simboluri\n\n", len(tm.States), len(tm.TapeAlphabet))
+
+	return tm
+}
+
+func runTuring(tm *automaton.TuringMachine, scanner *bufio.Scanner) {
+	fmt.Print("\nIntroduceți intrarea de pe bandă: ")
+	if !scanner.Scan() {
+		return
+	}
+	sequence := strings.TrimSpace(scanner.Text())
+
+	fmt.Printf("Limita de pași (gol pentru %d): ", automaton.DefaultTMStepLimit)
+	if !scanner.Scan() {
+		return
+	}
+	stepLimit := automaton.DefaultTMStepLimit
+	if text := strings.TrimSpace(scanner.Text()); text != "" {
+		limit, err := strconv.Atoi(text)
+		if err != nil || limit <= 0 {
+			fmt.Print("\nLimită invalidă! Se folosește valoarea implicită.\n")
+		} else {
+			stepLimit = limit
+		}
+	}
+
+	result := tm.SimulateWithStepLimit(sequence, stepLimit)
+
+	fmt.Println("\n=== Rezultat Execuție ===")
+
+	if result.Error != nil {
+		displayError(result.Error)
+	} else {
+		fmt.Println("ACCEPTAT")
+		fmt.Printf("Mașina s-a oprit în starea finală %s.\n", strings.Join(result.FinalStates, ", "))
+	}
+
+	fmt.Printf("\nPași efectuați: %d\n", len(result.Steps))
+	if len(result.Steps) > 0 {
+		last := result.Steps[len(result.Steps)-1]
+		for i, tape := range last.Tape {
+			fmt.Printf("Banda %d finală: %s\n", i+1, formatTape(tape))
+		}
+	}
+	fmt.Println()
+
+	fmt.Print("Doriți să vedeți pașii detaliat? (da/nu): ")
+	if scanner.Scan() && strings.ToLower(strings.TrimSpace(scanner.Text())) == "da" {
+		displaySteps(result.Steps, sequence)
+	}
+	fmt.Println()
+}
+
+func formatTape(tape automaton.TapeSnapshot) string {
+	cells := make([]string, len(tape.Cells))
+	for i, cell := range tape.Cells {
+		if tape.Offset+i == tape.Head {
+			cells[i] = "[" + cell + "]"
+		} else {
+			cells[i] = cell
+		}
+	}
+	return strings.Join(cells, " ")
+}
@@ -3,7 +3,11 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
 	"syscall/js"
+
+	"github.com/bujor/compilers/shared/automaton"
 )
 
 func main() {
@@ -11,6 +15,19 @@ func main() {
 	js.Global().Set("simulateSequence", js.FuncOf(simulateSequenceWASM))
 	js.Global().Set("findLongestPrefix", js.FuncOf(findLongestPrefixWASM))
 
+	// Transducers
+	js.Global().Set("parseTransducer", js.FuncOf(parseTransducerWASM))
+	js.Global().Set("translateSequence", js.FuncOf(translateSequenceWASM))
+	js.Global().Set("convertTransducer", js.FuncOf(convertTransducerWASM))
+
+	// Push-down automata
+	js.Global().Set("parsePushdown", js.FuncOf(parsePushdownWASM))
+	js.Global().Set("simulatePushdown", js.FuncOf(simulatePushdownWASM))
+
+	// Turing machines
+	js.Global().Set("parseTuring", js.FuncOf(parseTuringWASM))
+	js.Global().Set("simulateTuring", js.FuncOf(simulateTuringWASM))
+
 	// Edit operations
 	js.Global().Set("addState", js.FuncOf(addStateWASM))
 	js.Global().Set("removeState", js.FuncOf(removeStateWASM))
@@ -22,6 +39,22 @@ func main() {
 	js.Global().Set("removeTransition", js.FuncOf(removeTransitionWASM))
 	js.Global().Set("getAutomatonJSON", js.FuncOf(getAutomatonJSONWASM))
 
+	// Editor with undo/redo history
+	js.Global().Set("editorCreate", js.FuncOf(editorCreateWASM))
+	js.Global().Set("editorApply", js.FuncOf(editorApplyWASM))
+	js.Global().Set("editorUndo", js.FuncOf(editorUndoWASM))
+	js.Global().Set("editorRedo", js.FuncOf(editorRedoWASM))
+	js.Global().Set("editorBegin", js.FuncOf(editorBeginWASM))
+	js.Global().Set("editorCommit", js.FuncOf(editorCommitWASM))
+	js.Global().Set("editorRollback", js.FuncOf(editorRollbackWASM))
+
+	// Step-by-step constructions
+	js.Global().Set("constructionTrace", js.FuncOf(constructionTraceWASM))
+	js.Global().Set("tableFilling", js.FuncOf(tableFillingWASM))
+
+	// Weighted automata
+	js.Global().Set("mostLikelyPath", js.FuncOf(mostLikelyPathWASM))
+
 	<-make(chan bool)
 }
 
@@ -33,7 +66,7 @@ func parseAutomatonWASM(this js.Value, args []js.Value) interface{} {
 	}
 
 	jsonStr := args[0].String()
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"error": err.Error(),
@@ -41,11 +74,11 @@ func parseAutomatonWASM(this js.Value, args []js.Value) interface{} {
 	}
 
 	return map[string]interface{}{
-		"success":        true,
+		"success":         true,
 		"isDeterministic": fa.IsDeterministic(),
-		"states":         len(fa.States),
-		"alphabet":       len(fa.Alphabet),
-		"type":           fa.typeString(),
+		"states":          len(fa.States),
+		"alphabet":        len(fa.Alphabet),
+		"type":            fa.TypeString(),
 	}
 }
 
@@ -59,14 +92,14 @@ func simulateSequenceWASM(this js.Value, args []js.Value) interface{} {
 	jsonStr := args[0].String()
 	sequence := args[1].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"error": err.Error(),
 		}
 	}
 
-	result := fa.Simulate(sequence)
+	result := fa.SimulateWithRepair(sequence)
 
 	return serializeSimulationResult(result)
 }
@@ -81,7 +114,7 @@ func findLongestPrefixWASM(this js.Value, args []js.Value) interface{} {
 	jsonStr := args[0].String()
 	sequence := args[1].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"error": err.Error(),
@@ -96,7 +129,7 @@ func findLongestPrefixWASM(this js.Value, args []js.Value) interface{} {
 	return response
 }
 
-func serializeSimulationResult(result SimulationResult) map[string]interface{} {
+func serializeSimulationResult(result automaton.SimulationResult) map[string]interface{} {
 	finalStatesArr := make([]interface{}, len(result.FinalStates))
 	for i, s := range result.FinalStates {
 		finalStatesArr[i] = s
@@ -106,6 +139,9 @@ func serializeSimulationResult(result SimulationResult) map[string]interface{} {
 		"accepted":    result.Accepted,
 		"finalStates": finalStatesArr,
 	}
+	if result.Weight != nil {
+		response["weight"] = *result.Weight
+	}
 
 	steps := make([]interface{}, len(result.Steps))
 	for i, step := range result.Steps {
@@ -123,12 +159,39 @@ func serializeSimulationResult(result SimulationResult) map[string]interface{} {
 			}
 		}
 
-		steps[i] = map[string]interface{}{
+		stepMap := map[string]interface{}{
 			"activeStates": activeStatesArr,
 			"charIndex":    step.CharIndex,
 			"symbol":       step.Symbol,
 			"transitions":  transitions,
+			"output":       step.Output,
+		}
+
+		if step.Stack != nil {
+			stackArr := make([]interface{}, len(step.Stack))
+			for j, symbol := range step.Stack {
+				stackArr[j] = symbol
+			}
+			stepMap["stack"] = stackArr
 		}
+
+		if step.Tape != nil {
+			tapesArr := make([]interface{}, len(step.Tape))
+			for j, tape := range step.Tape {
+				cellsArr := make([]interface{}, len(tape.Cells))
+				for k, cell := range tape.Cells {
+					cellsArr[k] = cell
+				}
+				tapesArr[j] = map[string]interface{}{
+					"cells":  cellsArr,
+					"offset": tape.Offset,
+					"head":   tape.Head,
+				}
+			}
+			stepMap["tape"] = tapesArr
+		}
+
+		steps[i] = stepMap
 	}
 	response["steps"] = steps
 
@@ -145,6 +208,24 @@ func serializeSimulationResult(result SimulationResult) map[string]interface{} {
 			"symbol":   result.Error.Symbol,
 			"message":  result.Error.Message,
 		}
+
+		if repair := result.Error.Suggestion; repair != nil {
+			operations := make([]interface{}, len(repair.Operations))
+			for i, op := range repair.Operations {
+				operations[i] = map[string]interface{}{
+					"type":     op.Type,
+					"position": op.Position,
+					"symbol":   op.Symbol,
+					"original": op.Original,
+				}
+			}
+			response["error"].(map[string]interface{})["suggestion"] = map[string]interface{}{
+				"output":     repair.Output,
+				"distance":   repair.Distance,
+				"operations": operations,
+				"hint":       repair.Hint(),
+			}
+		}
 	}
 
 	return response
@@ -161,7 +242,7 @@ func addStateWASM(this js.Value, args []js.Value) interface{} {
 	jsonStr := args[0].String()
 	stateName := args[1].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -202,7 +283,7 @@ func removeStateWASM(this js.Value, args []js.Value) interface{} {
 	jsonStr := args[0].String()
 	stateName := args[1].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -244,7 +325,7 @@ func renameStateWASM(this js.Value, args []js.Value) interface{} {
 	oldName := args[1].String()
 	newName := args[2].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -285,7 +366,7 @@ func setInitialStateWASM(this js.Value, args []js.Value) interface{} {
 	jsonStr := args[0].String()
 	stateName := args[1].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -326,7 +407,7 @@ func toggleFinalStateWASM(this js.Value, args []js.Value) interface{} {
 	jsonStr := args[0].String()
 	stateName := args[1].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -369,7 +450,7 @@ func setStatePositionWASM(this js.Value, args []js.Value) interface{} {
 	x := args[2].Float()
 	y := args[3].Float()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -412,7 +493,7 @@ func addTransitionWASM(this js.Value, args []js.Value) interface{} {
 	symbol := args[2].String()
 	to := args[3].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -455,7 +536,7 @@ func removeTransitionWASM(this js.Value, args []js.Value) interface{} {
 	symbol := args[2].String()
 	to := args[3].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -495,7 +576,7 @@ func getAutomatonJSONWASM(this js.Value, args []js.Value) interface{} {
 
 	jsonStr := args[0].String()
 
-	fa, err := ParseFromJSON(jsonStr)
+	fa, err := automaton.ParseFromJSON(jsonStr)
 	if err != nil {
 		return map[string]interface{}{
 			"success": false,
@@ -516,3 +597,461 @@ func getAutomatonJSONWASM(this js.Value, args []js.Value) interface{} {
 		"data":    updatedJSON,
 	}
 }
+
+func parseTransducerWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return map[string]interface{}{
+			"error": "Se așteaptă exact un argument (JSON string)",
+		}
+	}
+
+	tr, err := automaton.ParseTransducerFromJSON(args[0].String())
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"type":    tr.TypeString(),
+	}
+}
+
+func translateSequenceWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return map[string]interface{}{
+			"error": "Se așteaptă 2 argumente (JSON traductor, secvență)",
+		}
+	}
+
+	jsonStr := args[0].String()
+	sequence := args[1].String()
+
+	tr, err := automaton.ParseTransducerFromJSON(jsonStr)
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	output, result := tr.Simulate(sequence)
+
+	response := serializeSimulationResult(result)
+	response["output"] = output
+
+	return response
+}
+
+func convertTransducerWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Se așteaptă 1 argument (JSON traductor)",
+		}
+	}
+
+	tr, err := automaton.ParseTransducerFromJSON(args[0].String())
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	var converted automaton.Transducer
+	switch t := tr.(type) {
+	case *automaton.MealyMachine:
+		converted = t.ToMoore()
+	case *automaton.MooreMachine:
+		converted = t.ToMealy()
+	}
+
+	updatedJSON, err := converted.ToJSON()
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"data":    updatedJSON,
+	}
+}
+
+func parsePushdownWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return map[string]interface{}{
+			"error": "Se așteaptă exact un argument (JSON string)",
+		}
+	}
+
+	pda, err := automaton.ParsePushdownFromJSON(args[0].String())
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	graphJSON, err := pda.GraphView().ToJSON()
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success":         true,
+		"isDeterministic": pda.IsDeterministic(),
+		"states":          len(pda.States),
+		"alphabet":        len(pda.Alphabet),
+		"stackAlphabet":   len(pda.StackAlphabet),
+		"type":            pda.TypeString(),
+		"graph":           graphJSON,
+	}
+}
+
+func simulatePushdownWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 && len(args) != 3 {
+		return map[string]interface{}{
+			"error": "Se așteaptă 2 sau 3 argumente (JSON automat, secvență, limită de adâncime)",
+		}
+	}
+
+	jsonStr := args[0].String()
+	sequence := args[1].String()
+	depthLimit := automaton.DefaultPDADepthLimit
+	if len(args) == 3 && args[2].Type() == js.TypeNumber {
+		depthLimit = args[2].Int()
+	}
+
+	pda, err := automaton.ParsePushdownFromJSON(jsonStr)
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	result := pda.SimulateWithDepthLimit(sequence, depthLimit)
+
+	return serializeSimulationResult(result)
+}
+
+func parseTuringWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return map[string]interface{}{
+			"error": "Se așteaptă exact un argument (JSON string)",
+		}
+	}
+
+	tm, err := automaton.ParseTuringFromJSON(args[0].String())
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	graphJSON, err := tm.GraphView().ToJSON()
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success":      true,
+		"states":       len(tm.States),
+		"tapeAlphabet": len(tm.TapeAlphabet),
+		"tapes":        tm.TapeCount(),
+		"type":         tm.TypeString(),
+		"graph":        graphJSON,
+	}
+}
+
+func simulateTuringWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 && len(args) != 3 {
+		return map[string]interface{}{
+			"error": "Se așteaptă 2 sau 3 argumente (JSON mașină, intrare, limită de pași)",
+		}
+	}
+
+	jsonStr := args[0].String()
+	sequence := args[1].String()
+	stepLimit := automaton.DefaultTMStepLimit
+	if len(args) == 3 && args[2].Type() == js.TypeNumber {
+		stepLimit = args[2].Int()
+	}
+
+	tm, err := automaton.ParseTuringFromJSON(jsonStr)
+	if err != nil {
+		return map[string]interface{}{
+			"error": err.Error(),
+		}
+	}
+
+	result := tm.SimulateWithStepLimit(sequence, stepLimit)
+
+	return serializeSimulationResult(result)
+}
+
+// The editor is passed between calls as JSON (automaton plus history), like
+// the automaton in the edit operations above.
+func editorResponse(e *automaton.Editor, err error) interface{} {
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	editorJSON, err := e.ToJSON()
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	automatonJSON, err := e.Automaton.ToJSON()
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success":   true,
+		"data":      editorJSON,
+		"automaton": automatonJSON,
+		"canUndo":   e.CanUndo(),
+		"canRedo":   e.CanRedo(),
+	}
+}
+
+func editorCreateWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Se așteaptă 1 argument (JSON automat)",
+		}
+	}
+
+	fa, err := automaton.ParseFromJSON(args[0].String())
+	if err != nil {
+		return editorResponse(nil, err)
+	}
+
+	return editorResponse(automaton.NewEditor(fa), nil)
+}
+
+// editorCall parses the editor from args[0] and runs action on it.
+func editorCall(args []js.Value, argCount int, usage string, action func(e *automaton.Editor) error) interface{} {
+	if len(args) != argCount {
+		return map[string]interface{}{
+			"success": false,
+			"error":   usage,
+		}
+	}
+
+	e, err := automaton.ParseEditorFromJSON(args[0].String())
+	if err != nil {
+		return editorResponse(nil, err)
+	}
+
+	return editorResponse(e, action(e))
+}
+
+func editorApplyWASM(this js.Value, args []js.Value) interface{} {
+	return editorCall(args, 2, "Se așteaptă 2 argumente (JSON editor, JSON comandă)", func(e *automaton.Editor) error {
+		var cmd automaton.EditCommand
+		if err := json.Unmarshal([]byte(args[1].String()), &cmd); err != nil {
+			return fmt.Errorf("comandă invalidă: %v", err)
+		}
+		return e.Apply(cmd)
+	})
+}
+
+func editorUndoWASM(this js.Value, args []js.Value) interface{} {
+	return editorCall(args, 1, "Se așteaptă 1 argument (JSON editor)", func(e *automaton.Editor) error {
+		return e.Undo()
+	})
+}
+
+func editorRedoWASM(this js.Value, args []js.Value) interface{} {
+	return editorCall(args, 1, "Se așteaptă 1 argument (JSON editor)", func(e *automaton.Editor) error {
+		return e.Redo()
+	})
+}
+
+func editorBeginWASM(this js.Value, args []js.Value) interface{} {
+	return editorCall(args, 2, "Se așteaptă 2 argumente (JSON editor, etichetă)", func(e *automaton.Editor) error {
+		return e.Begin(args[1].String())
+	})
+}
+
+func editorCommitWASM(this js.Value, args []js.Value) interface{} {
+	return editorCall(args, 1, "Se așteaptă 1 argument (JSON editor)", func(e *automaton.Editor) error {
+		return e.Commit()
+	})
+}
+
+func editorRollbackWASM(this js.Value, args []js.Value) interface{} {
+	return editorCall(args, 1, "Se așteaptă 1 argument (JSON editor)", func(e *automaton.Editor) error {
+		return e.Rollback()
+	})
+}
+
+// constructionTraceWASM runs one construction on an automaton and returns the
+// result together with its steps. The algorithm is "determinize",
+// "minimize", "epsilon" or "intersection"; the last one takes the second
+// automaton as a third argument.
+func constructionTraceWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Se așteaptă cel puțin 2 argumente (JSON automat, algoritm)",
+		}
+	}
+
+	fa, err := automaton.ParseFromJSON(args[0].String())
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	var result *automaton.FiniteAutomaton
+	var trace *automaton.ConstructionTrace
+	switch args[1].String() {
+	case "determinize":
+		result, trace = automaton.DeterminizeWithTrace(fa)
+	case "minimize":
+		result, trace, err = automaton.MinimizeWithTrace(fa)
+	case "epsilon":
+		result, trace = fa.RemoveEpsilonWithTrace()
+	case "intersection":
+		if len(args) != 3 {
+			return map[string]interface{}{
+				"success": false,
+				"error":   "Intersecția necesită al doilea automat (JSON)",
+			}
+		}
+		other, parseErr := automaton.ParseFromJSON(args[2].String())
+		if parseErr != nil {
+			err = parseErr
+			break
+		}
+		result, trace = automaton.IntersectionWithTrace(fa, other)
+	default:
+		err = fmt.Errorf("algoritm necunoscut: '%s'", args[1].String())
+	}
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	resultJSON, err := result.ToJSON()
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+	traceJSON, err := json.Marshal(trace)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"data":    resultJSON,
+		"trace":   string(traceJSON),
+	}
+}
+
+// tableFillingWASM returns the Myhill–Nerode pair table of a DFA as JSON.
+func tableFillingWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 1 {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Se așteaptă 1 argument (JSON automat)",
+		}
+	}
+
+	fa, err := automaton.ParseFromJSON(args[0].String())
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	table, err := fa.TableFilling()
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	tableJSON, err := json.Marshal(table)
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	return map[string]interface{}{
+		"success": true,
+		"data":    string(tableJSON),
+	}
+}
+
+// mostLikelyPathWASM returns the weight of a sequence on a weighted automaton
+// and its best accepting path.
+func mostLikelyPathWASM(this js.Value, args []js.Value) interface{} {
+	if len(args) != 2 {
+		return map[string]interface{}{
+			"success": false,
+			"error":   "Se așteaptă 2 argumente (JSON automat, secvență)",
+		}
+	}
+
+	fa, err := automaton.ParseFromJSON(args[0].String())
+	if err != nil {
+		return map[string]interface{}{
+			"success": false,
+			"error":   err.Error(),
+		}
+	}
+
+	path, found := fa.MostLikelyPath(args[1].String())
+	if !found {
+		return map[string]interface{}{
+			"success": true,
+			"found":   false,
+		}
+	}
+
+	states := make([]interface{}, len(path.States))
+	for i, state := range path.States {
+		states[i] = state
+	}
+	return map[string]interface{}{
+		"success": true,
+		"found":   true,
+		"states":  states,
+		"weight":  path.Weight,
+		"total":   fa.Weight(args[1].String()),
+	}
+}
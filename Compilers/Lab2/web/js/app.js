@@ -1,4 +1,5 @@
 let currentAutomaton = null;
+let currentMachine = null; // { kind: 'pushdown' | 'turing', json } shown through a read-only graph view
 let editor = null;
 let isPlaying = false;
 let currentStep = -1;
@@ -107,6 +108,7 @@ async function handleNew() {
             finalStates: [],
             positions: {}
         };
+        currentMachine = null;
         editor.loadAutomaton(currentAutomaton);
         hideStatus();
     }
@@ -122,12 +124,32 @@ async function handleFileUpload(e) {
             const jsonStr = event.target.result;
             const automaton = JSON.parse(jsonStr);
 
+            // Push-down automata and Turing machines are drawn through their graph view
+            const machineKind = automaton.stackAlphabet ? 'pushdown'
+                : automaton.tapeAlphabet ? 'turing' : null;
+            if (machineKind) {
+                const machineValidation = machineKind === 'pushdown'
+                    ? await wasmAutomaton.parsePushdown(jsonStr)
+                    : await wasmAutomaton.parseTuring(jsonStr);
+                if (machineValidation.error) {
+                    showStatus('error', 'Automat invalid: ' + machineValidation.error);
+                    return;
+                }
+
+                currentMachine = { kind: machineKind, json: jsonStr };
+                currentAutomaton = JSON.parse(machineValidation.graph);
+                editor.loadAutomaton(currentAutomaton);
+                hideStatus();
+                return;
+            }
+
             const validation = await wasmAutomaton.parseAutomaton(jsonStr);
             if (validation.error) {
                 showStatus('error', 'Automat invalid: ' + validation.error);
                 return;
             }
 
+            currentMachine = null;
             currentAutomaton = automaton;
             editor.loadAutomaton(automaton);
             hideStatus();
@@ -143,7 +165,7 @@ async function handleFileUpload(e) {
 function handleExport() {
     updateAutomaton();
 
-    const json = JSON.stringify(currentAutomaton, null, 2);
+    const json = currentMachine ? currentMachine.json : JSON.stringify(currentAutomaton, null, 2);
     const blob = new Blob([json], { type: 'application/json' });
     const url = URL.createObjectURL(blob);
     const a = document.createElement('a');
@@ -156,6 +178,9 @@ function handleExport() {
 }
 
 function updateAutomaton() {
+    // Graph views are read-only; the machine JSON stays authoritative
+    if (currentMachine) return;
+
     currentAutomaton = editor.toAutomaton();
 }
 
@@ -167,8 +192,14 @@ async function handlePlay() {
     sequence = document.getElementById('sequence-input').value;
 
     try {
-        const jsonStr = JSON.stringify(currentAutomaton);
-        simulationResult = await wasmAutomaton.simulateSequence(jsonStr, sequence);
+        if (currentMachine && currentMachine.kind === 'pushdown') {
+            simulationResult = await wasmAutomaton.simulatePushdown(currentMachine.json, sequence);
+        } else if (currentMachine && currentMachine.kind === 'turing') {
+            simulationResult = await wasmAutomaton.simulateTuring(currentMachine.json, sequence);
+        } else {
+            const jsonStr = JSON.stringify(currentAutomaton);
+            simulationResult = await wasmAutomaton.simulateSequence(jsonStr, sequence);
+        }
 
         console.log('Simulation result:', simulationResult);
 
@@ -187,9 +218,14 @@ async function handlePlay() {
     }
 }
 
-function renderSequence(charIndex) {
+function renderSequence(charIndex, step) {
     const seqDiv = document.getElementById('sequence-display');
 
+    if (step && step.tape) {
+        renderTapes(step.tape);
+        return;
+    }
+
     if (!sequence) {
         seqDiv.innerHTML = '';
         return;
@@ -207,6 +243,26 @@ function renderSequence(charIndex) {
     }
     html += '</div>';
 
+    if (step && step.stack) {
+        html += `<div class="stack-display">Stivă: ${step.stack.join('') || 'ε'}</div>`;
+    }
+
+    seqDiv.innerHTML = html;
+}
+
+function renderTapes(tapes) {
+    const seqDiv = document.getElementById('sequence-display');
+
+    let html = '';
+    for (const tape of tapes) {
+        html += '<div class="sequence-chars">';
+        tape.cells.forEach((cell, i) => {
+            const className = tape.offset + i === tape.head ? 'seq-char current' : 'seq-char';
+            html += `<span class="${className}">${cell}</span>`;
+        });
+        html += '</div>';
+    }
+
     seqDiv.innerHTML = html;
 }
 
@@ -237,7 +293,7 @@ function playNextStep() {
         // Step 2: Highlight transitions AND character being read
         editor.reset();
         editor.highlightTransitions(step.transitions);
-        renderSequence(step.charIndex); // Highlight current character
+        renderSequence(step.charIndex, step); // Highlight current character
 
         setTimeout(() => {
             if (!isPlaying) return;
@@ -275,6 +331,9 @@ function finishSimulation() {
             } else {
                 errorMsg += simulationResult.error.message;
             }
+            if (simulationResult.error.suggestion) {
+                errorMsg += '. ' + simulationResult.error.suggestion.hint;
+            }
             showStatus('error', errorMsg);
         } else {
             showStatus('error', 'Secvență respinsă');
@@ -84,6 +84,109 @@ class WasmAutomaton {
         await this.ensureReady();
         return removeTransition(automatonJSON, from, symbol, to);
     }
+
+    // Transducers
+    async parseTransducer(jsonStr) {
+        await this.ensureReady();
+        return parseTransducer(jsonStr);
+    }
+
+    async translateSequence(transducerJSON, sequence) {
+        await this.ensureReady();
+        return translateSequence(transducerJSON, sequence);
+    }
+
+    async convertTransducer(transducerJSON) {
+        await this.ensureReady();
+        return convertTransducer(transducerJSON);
+    }
+
+    // Push-down automata
+    async parsePushdown(jsonStr) {
+        await this.ensureReady();
+        return parsePushdown(jsonStr);
+    }
+
+    async simulatePushdown(automatonJSON, sequence, depthLimit) {
+        await this.ensureReady();
+        return simulatePushdown(automatonJSON, sequence, depthLimit);
+    }
+
+    // Turing machines
+    async parseTuring(jsonStr) {
+        await this.ensureReady();
+        return parseTuring(jsonStr);
+    }
+
+    async simulateTuring(machineJSON, input, stepLimit) {
+        await this.ensureReady();
+        return simulateTuring(machineJSON, input, stepLimit);
+    }
+
+    // Editor with undo/redo history; editorJSON is the `data` of the previous call
+    async editorCreate(automatonJSON) {
+        await this.ensureReady();
+        return editorCreate(automatonJSON);
+    }
+
+    async editorApply(editorJSON, command) {
+        await this.ensureReady();
+        return editorApply(editorJSON, JSON.stringify(command));
+    }
+
+    async editorUndo(editorJSON) {
+        await this.ensureReady();
+        return editorUndo(editorJSON);
+    }
+
+    async editorRedo(editorJSON) {
+        await this.ensureReady();
+        return editorRedo(editorJSON);
+    }
+
+    async editorBegin(editorJSON, label) {
+        await this.ensureReady();
+        return editorBegin(editorJSON, label);
+    }
+
+    async editorCommit(editorJSON) {
+        await this.ensureReady();
+        return editorCommit(editorJSON);
+    }
+
+    async editorRollback(editorJSON) {
+        await this.ensureReady();
+        return editorRollback(editorJSON);
+    }
+
+    // Step-by-step constructions; algorithm is "determinize", "minimize",
+    // "epsilon" or "intersection" (which needs otherJSON)
+    async constructionTrace(automatonJSON, algorithm, otherJSON) {
+        await this.ensureReady();
+        const result = otherJSON === undefined
+            ? constructionTrace(automatonJSON, algorithm)
+            : constructionTrace(automatonJSON, algorithm, otherJSON);
+        if (result.success) {
+            result.trace = JSON.parse(result.trace);
+        }
+        return result;
+    }
+
+    // Myhill–Nerode pair table of a DFA; data is {states, pairs, rounds}
+    async tableFilling(automatonJSON) {
+        await this.ensureReady();
+        const result = tableFilling(automatonJSON);
+        if (result.success) {
+            result.data = JSON.parse(result.data);
+        }
+        return result;
+    }
+
+    // Weighted automata: best accepting path (states, weight) and total weight
+    async mostLikelyPath(automatonJSON, sequence) {
+        await this.ensureReady();
+        return mostLikelyPath(automatonJSON, sequence);
+    }
 }
 
 const wasmAutomaton = new WasmAutomaton();
@@ -0,0 +1,385 @@
+package automaton
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExactSyncMaxStates bounds the subset search of ShortestSynchronizingWord,
+// which explores up to 2^n sets of states.
+const ExactSyncMaxStates = 16
+
+// StructuralAnalysis gathers the structural properties of a DFA shown in the
+// "display full automaton" view. Only the components are computed when the
+// automaton is not a complete DFA (Complete is false).
+type StructuralAnalysis struct {
+	Complete          bool       `json:"complete"` // deterministic and complete
+	Components        [][]string `json:"components"`
+	Permutation       bool       `json:"permutation"`
+	Reset             bool       `json:"reset"`
+	Synchronizing     bool       `json:"synchronizing"`
+	SynchronizingWord string     `json:"synchronizingWord"`
+	ShortestWord      bool       `json:"shortestWord"` // found by the exact search
+}
+
+// IsComplete reports whether every state has exactly one transition on every symbol.
+func (fa *FiniteAutomaton) IsComplete() bool {
+	for _, state := range fa.States {
+		for _, symbol := range fa.Alphabet {
+			if len(fa.Transitions[state][symbol]) != 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// transitionTable maps the complete DFA onto state indexes: table[s][a] is
+// the target of state s on fa.Alphabet[a].
+func (fa *FiniteAutomaton) transitionTable() ([][]int, error) {
+	if !fa.IsDeterministic() || !fa.IsComplete() {
+		return nil, fmt.Errorf("automatul trebuie să fie determinist și complet")
+	}
+
+	index := make(map[string]int)
+	for i, state := range fa.States {
+		index[state] = i
+	}
+
+	table := make([][]int, len(fa.States))
+	for i, state := range fa.States {
+		table[i] = make([]int, len(fa.Alphabet))
+		for a, symbol := range fa.Alphabet {
+			table[i][a] = index[fa.Transitions[state][symbol][0]]
+		}
+	}
+	return table, nil
+}
+
+// SynchronizingWord returns a word that brings every state to the same state,
+// using Eppstein's greedy algorithm: repeatedly apply the shortest word that
+// merges some pair of the current states. The word is not necessarily the
+// shortest one.
+func (fa *FiniteAutomaton) SynchronizingWord() (string, bool, error) {
+	table, err := fa.transitionTable()
+	if err != nil {
+		return "", false, err
+	}
+
+	n := len(fa.States)
+	merging := newMergingWords(table)
+	current := make(map[int]bool)
+	for i := 0; i < n; i++ {
+		current[i] = true
+	}
+
+	var word []int
+	for len(current) > 1 {
+		members := make([]int, 0, len(current))
+		for i := 0; i < n; i++ {
+			if current[i] {
+				members = append(members, i)
+			}
+		}
+
+		best := [2]int{-1, -1}
+		for i, p := range members {
+			for _, q := range members[i+1:] {
+				length := merging.length(p, q)
+				if length >= 0 && (best[0] < 0 || length < merging.length(best[0], best[1])) {
+					best = [2]int{p, q}
+				}
+			}
+		}
+		if best[0] < 0 {
+			return "", false, nil
+		}
+
+		merge := merging.word(best[0], best[1])
+		word = append(word, merge...)
+		next := make(map[int]bool)
+		for state := range current {
+			for _, a := range merge {
+				state = table[state][a]
+			}
+			next[state] = true
+		}
+		current = next
+	}
+
+	return fa.symbolWord(word), true, nil
+}
+
+// mergingWords holds, for every pair of states p and q, the length of a
+// shortest word w with δ(p, w) = δ(q, w) and its first symbol, computed by a
+// single breadth-first search backwards from the pairs with p = q.
+type mergingWords struct {
+	table   [][]int
+	n       int
+	lengths []int // -1 when the pair cannot be merged
+	first   []int
+}
+
+func newMergingWords(table [][]int) *mergingWords {
+	n := len(table)
+	m := &mergingWords{table: table, n: n, lengths: make([]int, n*n), first: make([]int, n*n)}
+	for i := range m.lengths {
+		m.lengths[i] = -1
+	}
+
+	symbols := 0
+	if n > 0 {
+		symbols = len(table[0])
+	}
+	// preimages[a][s] lists the states that reach s on symbol a.
+	preimages := make([][][]int, symbols)
+	for a := range preimages {
+		preimages[a] = make([][]int, n)
+	}
+	for state, row := range table {
+		for a, next := range row {
+			preimages[a][next] = append(preimages[a][next], state)
+		}
+	}
+
+	queue := make([][2]int, 0, n)
+	for state := 0; state < n; state++ {
+		m.lengths[state*n+state] = 0
+		queue = append(queue, [2]int{state, state})
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		length := m.lengths[current[0]*n+current[1]]
+
+		for a := range preimages {
+			for _, p := range preimages[a][current[0]] {
+				for _, q := range preimages[a][current[1]] {
+					pair := [2]int{min(p, q), max(p, q)}
+					if key := pair[0]*n + pair[1]; m.lengths[key] < 0 {
+						m.lengths[key] = length + 1
+						m.first[key] = a
+						queue = append(queue, pair)
+					}
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+func (m *mergingWords) length(p, q int) int {
+	if p > q {
+		p, q = q, p
+	}
+	return m.lengths[p*m.n+q]
+}
+
+// word spells the shortest merging word of p and q by following the first
+// symbols; the pair must be mergeable.
+func (m *mergingWords) word(p, q int) []int {
+	var word []int
+	for p != q {
+		if p > q {
+			p, q = q, p
+		}
+		a := m.first[p*m.n+q]
+		word = append(word, a)
+		p, q = m.table[p][a], m.table[q][a]
+	}
+	return word
+}
+
+// ShortestSynchronizingWord finds a shortest synchronizing word by a
+// breadth-first search over sets of states, for automata with at most
+// ExactSyncMaxStates states.
+func (fa *FiniteAutomaton) ShortestSynchronizingWord() (string, bool, error) {
+	table, err := fa.transitionTable()
+	if err != nil {
+		return "", false, err
+	}
+
+	n := len(fa.States)
+	if n > ExactSyncMaxStates {
+		return "", false, fmt.Errorf("căutarea exactă acceptă cel mult %d stări (automatul are %d)", ExactSyncMaxStates, n)
+	}
+
+	type visit struct {
+		parent uint32
+		symbol int
+	}
+
+	start := uint32(1)<<uint(n) - 1
+	visited := map[uint32]visit{start: {}}
+	queue := []uint32{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current&(current-1) == 0 {
+			var word []int
+			for current != start {
+				v := visited[current]
+				word = append([]int{v.symbol}, word...)
+				current = v.parent
+			}
+			return fa.symbolWord(word), true, nil
+		}
+
+		for a := range fa.Alphabet {
+			var next uint32
+			for state := 0; state < n; state++ {
+				if current&(1<<uint(state)) != 0 {
+					next |= 1 << uint(table[state][a])
+				}
+			}
+			if _, seen := visited[next]; !seen {
+				visited[next] = visit{parent: current, symbol: a}
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return "", false, nil
+}
+
+func (fa *FiniteAutomaton) symbolWord(word []int) string {
+	var sb strings.Builder
+	for _, a := range word {
+		sb.WriteString(fa.Alphabet[a])
+	}
+	return sb.String()
+}
+
+// StronglyConnectedComponents returns the strongly connected components of
+// the transition graph (Tarjan's algorithm), in reverse topological order:
+// no component has transitions into a later one.
+func (fa *FiniteAutomaton) StronglyConnectedComponents() [][]string {
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	stack := []string{}
+	components := [][]string{}
+	counter := 0
+
+	var visit func(state string)
+	visit = func(state string) {
+		index[state] = counter
+		lowLink[state] = counter
+		counter++
+		stack = append(stack, state)
+		onStack[state] = true
+
+		for _, symbol := range orderedKeys(fa.Alphabet, fa.Transitions[state]) {
+			for _, next := range fa.Transitions[state][symbol] {
+				if _, seen := index[next]; !seen {
+					visit(next)
+					if lowLink[next] < lowLink[state] {
+						lowLink[state] = lowLink[next]
+					}
+				} else if onStack[next] && index[next] < lowLink[state] {
+					lowLink[state] = index[next]
+				}
+			}
+		}
+
+		if lowLink[state] == index[state] {
+			component := []string{}
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append([]string{top}, component...)
+				if top == state {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+	}
+
+	for _, state := range fa.States {
+		if _, seen := index[state]; !seen {
+			visit(state)
+		}
+	}
+
+	return components
+}
+
+// IsPermutationAutomaton reports whether every symbol permutes the states.
+func (fa *FiniteAutomaton) IsPermutationAutomaton() bool {
+	table, err := fa.transitionTable()
+	if err != nil {
+		return false
+	}
+
+	for a := range fa.Alphabet {
+		if !isPermutation(table, a) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsResetAutomaton reports whether every symbol either permutes the states
+// or resets them, i.e. sends every state to the same state.
+func (fa *FiniteAutomaton) IsResetAutomaton() bool {
+	table, err := fa.transitionTable()
+	if err != nil {
+		return false
+	}
+
+	for a := range fa.Alphabet {
+		if !isPermutation(table, a) && !isConstant(table, a) {
+			return false
+		}
+	}
+	return true
+}
+
+func isPermutation(table [][]int, a int) bool {
+	seen := make(map[int]bool)
+	for state := range table {
+		seen[table[state][a]] = true
+	}
+	return len(seen) == len(table)
+}
+
+func isConstant(table [][]int, a int) bool {
+	for state := range table {
+		if table[state][a] != table[0][a] {
+			return false
+		}
+	}
+	return true
+}
+
+// Analyze computes the structural properties of the automaton. The
+// synchronizing word comes from the exact search when the automaton is small
+// enough and from the greedy algorithm otherwise.
+func (fa *FiniteAutomaton) Analyze() StructuralAnalysis {
+	analysis := StructuralAnalysis{
+		Complete:   fa.IsDeterministic() && fa.IsComplete(),
+		Components: fa.StronglyConnectedComponents(),
+	}
+
+	if !analysis.Complete {
+		return analysis
+	}
+
+	analysis.Permutation = fa.IsPermutationAutomaton()
+	analysis.Reset = fa.IsResetAutomaton()
+
+	if len(fa.States) <= ExactSyncMaxStates {
+		analysis.SynchronizingWord, analysis.Synchronizing, _ = fa.ShortestSynchronizingWord()
+		analysis.ShortestWord = analysis.Synchronizing
+	} else {
+		analysis.SynchronizingWord, analysis.Synchronizing, _ = fa.SynchronizingWord()
+	}
+
+	return analysis
+}
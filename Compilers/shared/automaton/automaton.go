@@ -11,12 +11,24 @@ type Position struct {
 }
 
 type FiniteAutomaton struct {
+	Version      int                            `json:"version,omitempty"`
+	Name         string                         `json:"name,omitempty"`
+	Description  string                         `json:"description,omitempty"`
+	Author       string                         `json:"author,omitempty"`
+	Tags         []string                       `json:"tags,omitempty"`
 	States       []string                       `json:"states"`
 	Alphabet     []string                       `json:"alphabet"`
 	Transitions  map[string]map[string][]string `json:"transitions"`
 	InitialState string                         `json:"initialState"`
 	FinalStates  []string                       `json:"finalStates"`
 	Positions    map[string]Position            `json:"positions,omitempty"`
+	Tests        []EmbeddedTest                 `json:"tests,omitempty"`
+
+	// Optional weights over Semiring, see weighted.go; missing weights
+	// default to the semiring's one.
+	Semiring     string                                   `json:"semiring,omitempty"`
+	Weights      map[string]map[string]map[string]float64 `json:"weights,omitempty"`
+	FinalWeights map[string]float64                       `json:"finalWeights,omitempty"`
 }
 
 type SimulationResult struct {
@@ -24,6 +36,7 @@ type SimulationResult struct {
 	Error       *SimulationError `json:"error,omitempty"`
 	Steps       []Step           `json:"steps"`
 	FinalStates []string         `json:"finalStates"`
+	Weight      *float64         `json:"weight,omitempty"` // accepted input on a weighted automaton
 }
 
 type SimulationError struct {
@@ -32,13 +45,19 @@ type SimulationError struct {
 	States   []string `json:"states"`
 	Symbol   string   `json:"symbol"`
 	Message  string   `json:"message"`
+
+	// Suggestion is the nearest accepted string, set by SimulateWithRepair.
+	Suggestion *Repair `json:"suggestion,omitempty"`
 }
 
 type Step struct {
-	ActiveStates []string     `json:"activeStates"`
-	CharIndex    int          `json:"charIndex"`
-	Symbol       string       `json:"symbol"`
-	Transitions  []Transition `json:"transitions"`
+	ActiveStates []string       `json:"activeStates"`
+	CharIndex    int            `json:"charIndex"`
+	Symbol       string         `json:"symbol"`
+	Transitions  []Transition   `json:"transitions"`
+	Output       string         `json:"output,omitempty"`
+	Stack        []string       `json:"stack,omitempty"` // top first
+	Tape         []TapeSnapshot `json:"tape,omitempty"`  // one per tape
 }
 
 type Transition struct {
@@ -47,7 +66,12 @@ type Transition struct {
 	Symbol string `json:"symbol"`
 }
 
+// IsDeterministic reports whether no state has two targets for a symbol and
+// there are no ε-transitions.
 func (fa *FiniteAutomaton) IsDeterministic() bool {
+	if fa.HasEpsilonTransitions() {
+		return false
+	}
 	for state := range fa.Transitions {
 		for _, nextStates := range fa.Transitions[state] {
 			if len(nextStates) > 1 {
@@ -58,6 +82,17 @@ func (fa *FiniteAutomaton) IsDeterministic() bool {
 	return true
 }
 
+// HasEpsilonTransitions reports whether some transition is labelled Epsilon,
+// i.e. changes state without reading a symbol.
+func (fa *FiniteAutomaton) HasEpsilonTransitions() bool {
+	for _, transitions := range fa.Transitions {
+		if len(transitions[Epsilon]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func (fa *FiniteAutomaton) Validate() error {
 	if len(fa.States) == 0 {
 		return fmt.Errorf("automatul trebuie să aibă cel puțin o stare")
@@ -67,6 +102,10 @@ func (fa *FiniteAutomaton) Validate() error {
 		return fmt.Errorf("automatul trebuie să aibă cel puțin un simbol în alfabet")
 	}
 
+	if contains(fa.Alphabet, Epsilon) {
+		return fmt.Errorf("simbolul '%s' este rezervat pentru tranzițiile fără citire", Epsilon)
+	}
+
 	if !contains(fa.States, fa.InitialState) {
 		return fmt.Errorf("starea inițială '%s' nu există în mulțimea stărilor", fa.InitialState)
 	}
@@ -83,7 +122,7 @@ func (fa *FiniteAutomaton) Validate() error {
 		}
 
 		for symbol, toStates := range transitions {
-			if !contains(fa.Alphabet, symbol) {
+			if symbol != Epsilon && !contains(fa.Alphabet, symbol) {
 				return fmt.Errorf("simbolul '%s' din tranziții nu există în alfabet", symbol)
 			}
 
@@ -95,7 +134,11 @@ func (fa *FiniteAutomaton) Validate() error {
 		}
 	}
 
-	return nil
+	if err := fa.validateWeights(); err != nil {
+		return err
+	}
+
+	return fa.validateTests()
 }
 
 func (fa *FiniteAutomaton) IsInAlphabet(symbol string) bool {
@@ -118,26 +161,45 @@ func (fa *FiniteAutomaton) String() string {
 
 	sb.WriteString("=== Automat Finit ===\n\n")
 
-	sb.WriteString(fmt.Sprintf("Tip: %s\n\n", fa.typeString()))
+	if fa.Name != "" {
+		sb.WriteString(fmt.Sprintf("Nume: %s\n", fa.Name))
+	}
+	if fa.Description != "" {
+		sb.WriteString(fmt.Sprintf("Descriere: %s\n", fa.Description))
+	}
+	if fa.Author != "" {
+		sb.WriteString(fmt.Sprintf("Autor: %s\n", fa.Author))
+	}
+	if len(fa.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Etichete: %s\n", strings.Join(fa.Tags, ", ")))
+	}
+
+	sb.WriteString(fmt.Sprintf("Tip: %s\n\n", fa.TypeString()))
 
 	sb.WriteString(fmt.Sprintf("Stări: {%s}\n", strings.Join(fa.States, ", ")))
 	sb.WriteString(fmt.Sprintf("Alfabet: {%s}\n", strings.Join(fa.Alphabet, ", ")))
 	sb.WriteString(fmt.Sprintf("Stare inițială: %s\n", fa.InitialState))
-	sb.WriteString(fmt.Sprintf("Stări finale: {%s}\n\n", strings.Join(fa.FinalStates, ", ")))
+	sb.WriteString(fmt.Sprintf("Stări finale: {%s}\n", strings.Join(fa.FinalStates, ", ")))
+	if fa.IsWeighted() {
+		sb.WriteString(fmt.Sprintf("Semiinel: %s\n", fa.semiring().Name))
+		for _, state := range orderedKeys(fa.FinalStates, fa.FinalWeights) {
+			sb.WriteString(fmt.Sprintf("Pondere finală %s: %s\n", state, FormatWeight(fa.FinalWeights[state])))
+		}
+	}
+	sb.WriteString("\n")
 
 	sb.WriteString("Tranziții:\n")
-	for state, transitions := range fa.Transitions {
-		for symbol, nextStates := range transitions {
-			for _, nextState := range nextStates {
-				sb.WriteString(fmt.Sprintf("  %s --%s--> %s\n", state, symbol, nextState))
-			}
-		}
+	for _, t := range fa.TransitionList() {
+		sb.WriteString(fmt.Sprintf("  %s --%s--> %s\n", t.From, fa.TransitionLabel(t), t.To))
 	}
 
 	return sb.String()
 }
 
-func (fa *FiniteAutomaton) typeString() string {
+func (fa *FiniteAutomaton) TypeString() string {
+	if fa.HasEpsilonTransitions() {
+		return "AFND-ε (Automat Finit Nedeterminist cu ε-tranziții)"
+	}
 	if fa.IsDeterministic() {
 		return "AFD (Automat Finit Determinist)"
 	}
@@ -229,6 +291,14 @@ func (fa *FiniteAutomaton) RemoveState(name string) error {
 		delete(fa.Positions, name)
 	}
 
+	delete(fa.Weights, name)
+	for _, bySymbol := range fa.Weights {
+		for _, byTarget := range bySymbol {
+			delete(byTarget, name)
+		}
+	}
+	delete(fa.FinalWeights, name)
+
 	if name == fa.InitialState && len(fa.States) > 0 {
 		fa.InitialState = fa.States[0]
 	}
@@ -289,6 +359,23 @@ func (fa *FiniteAutomaton) RenameState(oldName, newName string) error {
 		}
 	}
 
+	if weights, exists := fa.Weights[oldName]; exists {
+		fa.Weights[newName] = weights
+		delete(fa.Weights, oldName)
+	}
+	for _, bySymbol := range fa.Weights {
+		for _, byTarget := range bySymbol {
+			if w, exists := byTarget[oldName]; exists {
+				byTarget[newName] = w
+				delete(byTarget, oldName)
+			}
+		}
+	}
+	if w, exists := fa.FinalWeights[oldName]; exists {
+		fa.FinalWeights[newName] = w
+		delete(fa.FinalWeights, oldName)
+	}
+
 	return nil
 }
 
@@ -314,6 +401,7 @@ func (fa *FiniteAutomaton) ToggleFinalState(state string) error {
 			}
 		}
 		fa.FinalStates = newFinalStates
+		delete(fa.FinalWeights, state)
 	} else {
 		fa.FinalStates = append(fa.FinalStates, state)
 	}
@@ -391,6 +479,7 @@ func (fa *FiniteAutomaton) RemoveTransition(from, symbol, to string) error {
 	} else {
 		delete(fa.Transitions[from], symbol)
 	}
+	delete(fa.Weights[from][symbol], to)
 
 	return nil
 }
@@ -0,0 +1,425 @@
+package automaton
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TransitionList returns every transition in a stable order: states as listed
+// in States, symbols as listed in Alphabet, targets sorted. Entries for states
+// or symbols missing from those lists come last, sorted by name.
+func (fa *FiniteAutomaton) TransitionList() []Transition {
+	transitions := []Transition{}
+	for _, state := range orderedKeys(fa.States, fa.Transitions) {
+		byState := fa.Transitions[state]
+		for _, symbol := range orderedKeys(fa.Alphabet, byState) {
+			targets := append([]string{}, byState[symbol]...)
+			sort.Strings(targets)
+			for _, target := range targets {
+				transitions = append(transitions, Transition{From: state, To: target, Symbol: symbol})
+			}
+		}
+	}
+	return transitions
+}
+
+// orderedKeys lists the keys of m following order, then the remaining keys sorted.
+func orderedKeys[V any](order []string, m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	seen := make(map[string]bool)
+	for _, key := range order {
+		if _, exists := m[key]; exists && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	rest := []string{}
+	for key := range m {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+
+	return append(keys, rest...)
+}
+
+// canonicalSymbols is the sorted alphabet, followed by Epsilon when some
+// transition is labelled with it.
+func (fa *FiniteAutomaton) canonicalSymbols() []string {
+	symbols := append([]string{}, fa.Alphabet...)
+	sort.Strings(symbols)
+	if fa.HasEpsilonTransitions() {
+		symbols = append(symbols, Epsilon)
+	}
+	return symbols
+}
+
+// canonicalOrder lists the states in breadth-first order from the initial
+// state, following symbols in sorted order (ε last) and targets by name,
+// followed by the unreachable states sorted by name.
+func (fa *FiniteAutomaton) canonicalOrder() ([]string, map[string]string) {
+	symbols := fa.canonicalSymbols()
+
+	names := make(map[string]string)
+	order := []string{}
+	visit := func(state string) {
+		if _, named := names[state]; !named {
+			names[state] = fmt.Sprintf("q%d", len(order))
+			order = append(order, state)
+		}
+	}
+
+	if contains(fa.States, fa.InitialState) {
+		visit(fa.InitialState)
+	}
+	for i := 0; i < len(order); i++ {
+		for _, symbol := range symbols {
+			targets := append([]string{}, fa.Transitions[order[i]][symbol]...)
+			sort.Strings(targets)
+			for _, target := range targets {
+				visit(target)
+			}
+		}
+	}
+
+	unreachable := []string{}
+	for _, state := range fa.States {
+		if _, named := names[state]; !named {
+			unreachable = append(unreachable, state)
+		}
+	}
+	sort.Strings(unreachable)
+	for _, state := range unreachable {
+		visit(state)
+	}
+
+	return order, names
+}
+
+// Canonicalize returns a copy with the alphabet sorted and the states renamed
+// q0, q1, ... in breadth-first order from the initial state, following
+// symbols in alphabet order and ε-transitions last. Unreachable states come
+// last, ordered by their old names, so only Hash ignores them. Two DFAs that
+// differ only in state names and listing order have identical canonical
+// forms; for NFAs the targets of a transition are visited in the order of
+// their old names, so the form is only canonical up to that.
+func (fa *FiniteAutomaton) Canonicalize() *FiniteAutomaton {
+	order, names := fa.canonicalOrder()
+
+	alphabet := append([]string{}, fa.Alphabet...)
+	sort.Strings(alphabet)
+
+	symbols := fa.canonicalSymbols()
+	canonical := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     alphabet,
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: names[fa.InitialState],
+		FinalStates:  []string{},
+	}
+
+	for _, state := range order {
+		name := names[state]
+		canonical.States = append(canonical.States, name)
+		if fa.IsFinalState(state) {
+			canonical.FinalStates = append(canonical.FinalStates, name)
+		}
+
+		for _, symbol := range symbols {
+			targets := fa.Transitions[state][symbol]
+			if len(targets) == 0 {
+				continue
+			}
+
+			indexes := make([]int, 0, len(targets))
+			for _, target := range targets {
+				indexes = append(indexes, stateIndex(names[target]))
+			}
+			sort.Ints(indexes)
+
+			if canonical.Transitions[name] == nil {
+				canonical.Transitions[name] = make(map[string][]string)
+			}
+			for _, index := range indexes {
+				canonical.Transitions[name][symbol] = append(canonical.Transitions[name][symbol], fmt.Sprintf("q%d", index))
+			}
+		}
+	}
+
+	if fa.Positions != nil {
+		canonical.Positions = make(map[string]Position)
+		for state, position := range fa.Positions {
+			if name, exists := names[state]; exists {
+				canonical.Positions[name] = position
+			}
+		}
+	}
+
+	canonical.Semiring = fa.Semiring
+	for from, bySymbol := range fa.Weights {
+		for symbol, byTarget := range bySymbol {
+			for to, w := range byTarget {
+				canonical.SetTransitionWeight(names[from], symbol, names[to], w)
+			}
+		}
+	}
+	for state, w := range fa.FinalWeights {
+		canonical.SetFinalWeight(names[state], w)
+	}
+
+	return canonical
+}
+
+// stateIndex returns n for a canonical name "qn".
+func stateIndex(name string) int {
+	var index int
+	fmt.Sscanf(name, "q%d", &index)
+	return index
+}
+
+// canonicalText serialises the canonical form without positions. For a
+// weighted automaton it adds the semiring and the weight of every transition
+// and final state, explicit or not, so an explicit One equals a missing weight.
+func (fa *FiniteAutomaton) canonicalText() string {
+	canonical := fa.Canonicalize()
+	weighted := canonical.IsWeighted()
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("states=%d\n", len(canonical.States)))
+	sb.WriteString(fmt.Sprintf("alphabet=%q\n", canonical.Alphabet))
+	sb.WriteString(fmt.Sprintf("initial=%s\n", canonical.InitialState))
+	sb.WriteString(fmt.Sprintf("final=%q\n", canonical.FinalStates))
+	if weighted {
+		sb.WriteString(fmt.Sprintf("semiring=%s\n", canonical.semiring().Name))
+		for _, state := range canonical.FinalStates {
+			sb.WriteString(fmt.Sprintf("final %s %s\n", state, FormatWeight(canonical.FinalWeight(state))))
+		}
+	}
+	for _, t := range canonical.TransitionList() {
+		sb.WriteString(fmt.Sprintf("%s %q %s", t.From, t.Symbol, t.To))
+		if weighted {
+			sb.WriteString(" " + FormatWeight(canonical.TransitionWeight(t.From, t.Symbol, t.To)))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// reachablePart is trimmed together with the semiring and the weights of the
+// states it keeps.
+func (fa *FiniteAutomaton) reachablePart() *FiniteAutomaton {
+	result := fa.trimmed()
+	result.Semiring = fa.Semiring
+	for _, state := range result.States {
+		for symbol, byTarget := range fa.Weights[state] {
+			for to, w := range byTarget {
+				result.SetTransitionWeight(state, symbol, to, w)
+			}
+		}
+		if w, exists := fa.FinalWeights[state]; exists {
+			result.SetFinalWeight(state, w)
+		}
+	}
+	return result
+}
+
+// weightKind is the semiring of a weighted automaton and empty otherwise.
+func (fa *FiniteAutomaton) weightKind() string {
+	if !fa.IsWeighted() {
+		return ""
+	}
+	return fa.semiring().Name
+}
+
+// Hash is a SHA-256 digest of the canonical form of the states reachable
+// from the initial state, with their weights, ignoring positions. Unreachable
+// states are left out, since only their names could order them: DFAs whose
+// reachable parts are equal up to renaming states have the same hash,
+// whatever states they cannot reach.
+func (fa *FiniteAutomaton) Hash() string {
+	sum := sha256.Sum256([]byte(fa.reachablePart().canonicalText()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Isomorphic reports whether b can be obtained from a by renaming states:
+// same alphabet and semiring and a bijection between states that preserves
+// the initial state, the final states and every transition, with their
+// weights. Positions are ignored.
+func Isomorphic(a, b *FiniteAutomaton) bool {
+	_, found := Isomorphism(a, b)
+	return found
+}
+
+// Isomorphism returns a renaming of the states of a into the states of b
+// that witnesses Isomorphic(a, b).
+func Isomorphism(a, b *FiniteAutomaton) (map[string]string, bool) {
+	if len(a.States) != len(b.States) || len(a.FinalStates) != len(b.FinalStates) ||
+		a.weightKind() != b.weightKind() {
+		return nil, false
+	}
+
+	alphabetA := append([]string{}, a.Alphabet...)
+	alphabetB := append([]string{}, b.Alphabet...)
+	sort.Strings(alphabetA)
+	sort.Strings(alphabetB)
+	if strings.Join(alphabetA, "\x00") != strings.Join(alphabetB, "\x00") {
+		return nil, false
+	}
+
+	edgesA, edgesB := a.TransitionList(), b.TransitionList()
+	if len(edgesA) != len(edgesB) {
+		return nil, false
+	}
+
+	// Identical canonical forms settle every DFA with all states reachable:
+	// states with the same canonical name correspond. Otherwise search for a
+	// bijection.
+	orderA, namesA := a.canonicalOrder()
+	if a.canonicalText() == b.canonicalText() {
+		orderB, _ := b.canonicalOrder()
+		mapping := make(map[string]string)
+		for _, state := range orderA {
+			mapping[state] = orderB[stateIndex(namesA[state])]
+		}
+		return mapping, true
+	}
+
+	search := &isoSearch{
+		a:          a,
+		b:          b,
+		order:      orderA,
+		signatureA: stateSignatures(a, edgesA),
+		signatureB: stateSignatures(b, edgesB),
+		edgesB:     make(map[string]string),
+		outA:       make(map[string][]Transition),
+		inA:        make(map[string][]Transition),
+		mapping:    make(map[string]string),
+		used:       make(map[string]bool),
+	}
+	for _, t := range edgesA {
+		search.outA[t.From] = append(search.outA[t.From], t)
+		search.inA[t.To] = append(search.inA[t.To], t)
+	}
+	for _, t := range edgesB {
+		search.edgesB[edgeKey(t.From, t.Symbol, t.To)] = edgeWeight(b, t)
+	}
+
+	if !search.assign(0) {
+		return nil, false
+	}
+	return search.mapping, true
+}
+
+// isoSearch looks for an isomorphism by backtracking, assigning the states
+// of a in canonical order to states of b with the same local signature.
+type isoSearch struct {
+	a, b       *FiniteAutomaton
+	order      []string
+	signatureA map[string]string
+	signatureB map[string]string
+	edgesB     map[string]string // edge key to its weight
+	outA, inA  map[string][]Transition
+	mapping    map[string]string
+	used       map[string]bool
+}
+
+func edgeKey(from, symbol, to string) string {
+	return from + "\x00" + symbol + "\x00" + to
+}
+
+// edgeWeight prints the weight of t, or nothing for an unweighted automaton.
+func edgeWeight(fa *FiniteAutomaton, t Transition) string {
+	if !fa.IsWeighted() {
+		return ""
+	}
+	return FormatWeight(fa.TransitionWeight(t.From, t.Symbol, t.To))
+}
+
+// stateSignatures summarises each state by the properties an isomorphism
+// must preserve: initial, final (with its final weight), and the number of
+// outgoing and incoming transitions per symbol.
+func stateSignatures(fa *FiniteAutomaton, edges []Transition) map[string]string {
+	out := make(map[string]map[string]int)
+	in := make(map[string]map[string]int)
+	for _, state := range fa.States {
+		out[state] = make(map[string]int)
+		in[state] = make(map[string]int)
+	}
+	for _, t := range edges {
+		if out[t.From] != nil {
+			out[t.From][t.Symbol]++
+		}
+		if in[t.To] != nil {
+			in[t.To][t.Symbol]++
+		}
+	}
+
+	symbols := fa.canonicalSymbols()
+
+	signatures := make(map[string]string)
+	for _, state := range fa.States {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("%t %t", state == fa.InitialState, fa.IsFinalState(state)))
+		if fa.IsWeighted() {
+			sb.WriteString(" " + FormatWeight(fa.FinalWeight(state)))
+		}
+		for _, symbol := range symbols {
+			sb.WriteString(fmt.Sprintf(" %d/%d", out[state][symbol], in[state][symbol]))
+		}
+		signatures[state] = sb.String()
+	}
+	return signatures
+}
+
+func (s *isoSearch) assign(i int) bool {
+	if i == len(s.order) {
+		return true
+	}
+
+	state := s.order[i]
+	for _, candidate := range s.b.States {
+		if s.used[candidate] || s.signatureA[state] != s.signatureB[candidate] {
+			continue
+		}
+
+		s.mapping[state] = candidate
+		s.used[candidate] = true
+		if s.consistent(state) && s.assign(i+1) {
+			return true
+		}
+		delete(s.mapping, state)
+		s.used[candidate] = false
+	}
+
+	return false
+}
+
+// consistent checks the transitions between state and the states mapped so
+// far. Since both automata have the same number of transitions and every
+// transition of a is checked once both ends are mapped, a complete
+// consistent mapping is an isomorphism.
+func (s *isoSearch) consistent(state string) bool {
+	for _, t := range s.outA[state] {
+		if to, mapped := s.mapping[t.To]; mapped && !s.hasEdge(s.mapping[state], to, t) {
+			return false
+		}
+	}
+	for _, t := range s.inA[state] {
+		if from, mapped := s.mapping[t.From]; mapped && !s.hasEdge(from, s.mapping[state], t) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasEdge reports whether b has the image from --symbol--> to of the
+// transition t of a, with the same weight.
+func (s *isoSearch) hasEdge(from, to string, t Transition) bool {
+	w, exists := s.edgesB[edgeKey(from, t.Symbol, to)]
+	return exists && w == edgeWeight(s.a, t)
+}
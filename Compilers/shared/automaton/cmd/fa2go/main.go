@@ -0,0 +1,77 @@
+// Command fa2go turns a deterministic automaton into dependency-free Go
+// source with Match and LongestPrefix functions, in the spirit of re2go.
+//
+// Usage:
+//
+//	go run ./cmd/fa2go [-pkg main] [-name Identifier] [-style switch|table] [-o out.go] automaton.json
+//
+// Without -o the code is written to standard output. Nondeterministic
+// automata are rejected. Symbolic automata ("type": "symbolic") are
+// generated with range tables and only in the switch style.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func main() {
+	pkg := flag.String("pkg", "main", "pachetul codului generat")
+	name := flag.String("name", "", "sufixul numelor generate (MatchName, LongestPrefixName)")
+	style := flag.String("style", automaton.GoStyleSwitch, "stilul codului: switch sau table")
+	output := flag.String("o", "", "fișierul generat (implicit stdout)")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Utilizare: fa2go [-pkg p] [-name N] [-style switch|table] [-o fișier.go] automat.json")
+		os.Exit(2)
+	}
+
+	filename := flag.Arg(0)
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Eroare: %v\n", err)
+		os.Exit(1)
+	}
+
+	opts := automaton.GoOptions{
+		Package: *pkg,
+		Name:    *name,
+		Style:   *style,
+		Source:  filepath.Base(filename),
+	}
+	source, err := generate(string(data), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Eroare (%s): %v\n", filename, err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		fmt.Print(source)
+		return
+	}
+	if err := os.WriteFile(*output, []byte(source), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Eroare: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate(jsonStr string, opts automaton.GoOptions) (string, error) {
+	if automaton.IsSymbolicJSON(jsonStr) {
+		sa, err := automaton.ParseSymbolicFromJSON(jsonStr)
+		if err != nil {
+			return "", err
+		}
+		return automaton.GenerateSymbolicGo(sa, opts)
+	}
+
+	fa, err := automaton.ParseFromJSON(jsonStr)
+	if err != nil {
+		return "", err
+	}
+	return automaton.GenerateGo(fa, opts)
+}
@@ -0,0 +1,87 @@
+// Command facompile converts a deterministic automaton between the JSON
+// format and the compiled binary format read by automaton.LoadCompiled.
+//
+// Usage:
+//
+//	go run ./cmd/facompile [-o out.dfa] automaton.json
+//	go run ./cmd/facompile -d [-o out.json] automaton.dfa
+//
+// Without -o the output is written next to the input, with the extension
+// .dfa (or .json with -d).
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+func main() {
+	decompile := flag.Bool("d", false, "transformă un automat compilat înapoi în JSON")
+	output := flag.String("o", "", "fișierul generat (implicit lângă intrare)")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "Utilizare: facompile [-d] [-o fișier] automat.json|automat.dfa")
+		os.Exit(2)
+	}
+
+	filename := flag.Arg(0)
+	var err error
+	if *decompile {
+		err = toJSON(filename, outputName(filename, *output, ".json"))
+	} else {
+		err = toBinary(filename, outputName(filename, *output, ".dfa"))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Eroare (%s): %v\n", filename, err)
+		os.Exit(1)
+	}
+}
+
+func outputName(input, output, extension string) string {
+	if output != "" {
+		return output
+	}
+	return strings.TrimSuffix(input, filepath.Ext(input)) + extension
+}
+
+func toBinary(input, output string) error {
+	fa, err := automaton.ParseFromFile(input)
+	if err != nil {
+		return err
+	}
+	compiled, err := fa.Compile()
+	if err != nil {
+		return err
+	}
+	if err := compiled.Save(output); err != nil {
+		return err
+	}
+
+	// Loading it back checks the file and shows what it costs.
+	start := time.Now()
+	if _, err := automaton.LoadCompiled(output); err != nil {
+		return err
+	}
+	info, err := os.Stat(output)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: %d stări, %d simboluri în %d clase, %d octeți (încărcare %v)\n",
+		output, compiled.StateCount(), len(compiled.Symbols), compiled.ClassCount(), info.Size(), time.Since(start))
+	return nil
+}
+
+func toJSON(input, output string) error {
+	compiled, err := automaton.LoadCompiled(input)
+	if err != nil {
+		return err
+	}
+	return compiled.Automaton().SaveToFile(output)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+)
+
+const (
+	decimalDigits = "0123456789"
+	binaryDigits  = "01"
+	octalDigits   = "01234567"
+	hexDigits     = "0123456789abcdefABCDEF"
+	letters       = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ_"
+)
+
+// generator derives random literals from the productions of the Go spec
+// (https://go.dev/ref/spec#Integer_literals and the following sections).
+type generator struct {
+	rand *rand.Rand
+}
+
+func (g *generator) pick(chars string) string {
+	return string(chars[g.rand.Intn(len(chars))])
+}
+
+func (g *generator) maybe(s string) string {
+	if g.rand.Intn(2) == 0 {
+		return s
+	}
+	return ""
+}
+
+// digits = digit { [ "_" ] digit } .
+func (g *generator) digits(set string) string {
+	var sb strings.Builder
+	sb.WriteString(g.pick(set))
+	for n := g.rand.Intn(4); n > 0; n-- {
+		if g.rand.Intn(4) == 0 {
+			sb.WriteString("_")
+		}
+		sb.WriteString(g.pick(set))
+	}
+	return sb.String()
+}
+
+func (g *generator) integer() string {
+	switch g.rand.Intn(5) {
+	case 0:
+		if g.rand.Intn(3) == 0 {
+			return "0"
+		}
+		return g.pick("123456789") + g.maybe(g.maybe("_")+g.digits(decimalDigits))
+	case 1:
+		return "0" + g.pick("bB") + g.maybe("_") + g.digits(binaryDigits)
+	case 2:
+		return "0" + g.maybe(g.pick("oO")) + g.maybe("_") + g.digits(octalDigits)
+	default:
+		return "0" + g.pick("xX") + g.maybe("_") + g.digits(hexDigits)
+	}
+}
+
+func (g *generator) decimalExponent() string {
+	return g.pick("eE") + g.maybe(g.pick("+-")) + g.digits(decimalDigits)
+}
+
+func (g *generator) float() string {
+	switch g.rand.Intn(4) {
+	case 0:
+		return g.digits(decimalDigits) + "." + g.maybe(g.digits(decimalDigits)) + g.maybe(g.decimalExponent())
+	case 1:
+		return g.digits(decimalDigits) + g.decimalExponent()
+	case 2:
+		return "." + g.digits(decimalDigits) + g.maybe(g.decimalExponent())
+	default:
+		var mantissa string
+		switch g.rand.Intn(3) {
+		case 0:
+			mantissa = g.maybe("_") + g.digits(hexDigits) + "." + g.maybe(g.digits(hexDigits))
+		case 1:
+			mantissa = g.maybe("_") + g.digits(hexDigits)
+		default:
+			mantissa = "." + g.digits(hexDigits)
+		}
+		return "0" + g.pick("xX") + mantissa + g.pick("pP") + g.maybe(g.pick("+-")) + g.digits(decimalDigits)
+	}
+}
+
+func (g *generator) identifier() string {
+	var sb strings.Builder
+	sb.WriteString(g.pick(letters))
+	for n := g.rand.Intn(6); n > 0; n-- {
+		sb.WriteString(g.pick(letters + decimalDigits))
+	}
+	return sb.String()
+}
+
+func (g *generator) literal(definition string) string {
+	switch definition {
+	case "integer":
+		return g.integer()
+	case "float":
+		return g.float()
+	default:
+		return g.identifier()
+	}
+}
+
+// mutate inserts, deletes or replaces one character, so that near misses
+// of valid literals are tested as well.
+func (g *generator) mutate(s string, alphabet []string) string {
+	runes := []rune(s)
+	position := g.rand.Intn(len(runes) + 1)
+	symbol := []rune(alphabet[g.rand.Intn(len(alphabet))])
+
+	switch g.rand.Intn(3) {
+	case 0:
+		return string(runes[:position]) + string(symbol) + string(runes[position:])
+	case 1:
+		if position < len(runes) {
+			return string(runes[:position]) + string(runes[position+1:])
+		}
+	default:
+		if position < len(runes) {
+			return string(runes[:position]) + string(symbol) + string(runes[position+1:])
+		}
+	}
+	return s
+}
+
+func (g *generator) random(alphabet []string, maxLength int) string {
+	var sb strings.Builder
+	for n := g.rand.Intn(maxLength + 1); n > 0; n-- {
+		sb.WriteString(alphabet[g.rand.Intn(len(alphabet))])
+	}
+	return sb.String()
+}
+
+// exhaustive calls visit for every word over alphabet of at most maxLength symbols.
+func exhaustive(alphabet []string, maxLength int, visit func(string)) {
+	var extend func(prefix string, length int)
+	extend = func(prefix string, length int) {
+		visit(prefix)
+		if length == maxLength {
+			return
+		}
+		for _, symbol := range alphabet {
+			extend(prefix+symbol, length+1)
+		}
+	}
+	extend("", 0)
+}
@@ -0,0 +1,203 @@
+// Command verifydefs checks the automata in definitions/ against Go's own
+// scanner. It tests every short string over the automaton's alphabet, random
+// literals derived from the spec grammar (and mutations of them) and random
+// strings, and prints the smallest inputs on which the automaton and
+// go/scanner disagree, with the strconv verdict for reference.
+//
+// Usage, from shared/automaton:
+//
+//	go run ./cmd/verifydefs [-n 20000] [-length 3] [-seed 1] [identifier integer float]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+// extraSymbols are tested next to the automaton's alphabet, so that
+// characters it does not know about are covered too.
+var extraSymbols = []string{"g", "z", " ", "+", "-", ".", "_"}
+
+type disagreement struct {
+	input     string
+	automaton bool
+	scanner   bool
+	strconv   bool
+}
+
+func main() {
+	dir := flag.String("dir", "definitions", "directorul cu definițiile")
+	samples := flag.Int("n", 20000, "numărul de șiruri generate aleator")
+	length := flag.Int("length", 3, "lungimea maximă pentru testarea exhaustivă")
+	seed := flag.Int64("seed", 1, "sămânța generatorului aleator")
+	examples := flag.Int("examples", 10, "numărul maxim de exemple afișate")
+	flag.Parse()
+
+	definitions := flag.Args()
+	if len(definitions) == 0 {
+		definitions = []string{"identifier", "integer", "float"}
+	}
+
+	failed := false
+	for _, definition := range definitions {
+		filename := filepath.Join(*dir, definition+".json")
+		fa, err := automaton.ParseFromFile(filename)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Eroare (%s): %v\n", filename, err)
+			os.Exit(2)
+		}
+
+		tested, found := verify(definition, fa, *samples, *length, rand.New(rand.NewSource(*seed)))
+		report(definition, filename, tested, found, *examples)
+		if len(found) > 0 {
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func verify(definition string, fa *automaton.FiniteAutomaton, samples, length int, random *rand.Rand) (int, []disagreement) {
+	alphabet := []string{}
+	for _, symbol := range append(append([]string{}, fa.Alphabet...), extraSymbols...) {
+		if !containsString(alphabet, symbol) {
+			alphabet = append(alphabet, symbol)
+		}
+	}
+
+	seen := make(map[string]bool)
+	minimal := make(map[string]disagreement)
+
+	check := func(s string) {
+		if seen[s] {
+			return
+		}
+		seen[s] = true
+
+		if fa.Simulate(s).Accepted != goVerdict(definition, s) {
+			d := shrink(definition, fa, s)
+			minimal[d.input] = d
+		}
+	}
+
+	exhaustive(alphabet, length, check)
+
+	g := &generator{rand: random}
+	for i := 0; i < samples; i++ {
+		literal := g.literal(definition)
+		check(literal)
+		check(g.mutate(literal, alphabet))
+		check(g.random(alphabet, 12))
+	}
+
+	found := make([]disagreement, 0, len(minimal))
+	for _, d := range minimal {
+		found = append(found, d)
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if len(found[i].input) != len(found[j].input) {
+			return len(found[i].input) < len(found[j].input)
+		}
+		return found[i].input < found[j].input
+	})
+
+	return len(seen), found
+}
+
+// shrink deletes characters from a disagreeing input as long as the
+// automaton still gives the same verdict and go/scanner still disagrees.
+func shrink(definition string, fa *automaton.FiniteAutomaton, s string) disagreement {
+	verdict := fa.Simulate(s).Accepted
+
+	for shrunk := true; shrunk; {
+		shrunk = false
+		runes := []rune(s)
+		for i := range runes {
+			candidate := string(runes[:i]) + string(runes[i+1:])
+			if fa.Simulate(candidate).Accepted == verdict && goVerdict(definition, candidate) != verdict {
+				s = candidate
+				shrunk = true
+				break
+			}
+		}
+	}
+
+	return disagreement{
+		input:     s,
+		automaton: verdict,
+		scanner:   !verdict,
+		strconv:   strconvVerdict(definition, s),
+	}
+}
+
+func report(definition, filename string, tested int, found []disagreement, examples int) {
+	fmt.Printf("=== %s (%s) ===\n", definition, filename)
+	fmt.Printf("Șiruri testate: %d\n", tested)
+
+	if len(found) == 0 {
+		fmt.Print("Niciun dezacord cu go/scanner.\n\n")
+		return
+	}
+
+	// One example per shape, so that "01", "02", ... do not hide the others.
+	shapes := make(map[string]bool)
+	representatives := []disagreement{}
+	for _, d := range found {
+		if key := shape(d.input); !shapes[key] {
+			shapes[key] = true
+			representatives = append(representatives, d)
+		}
+	}
+
+	fmt.Printf("Dezacorduri minime: %d (%d tipare)\n", len(found), len(representatives))
+	fmt.Printf("  %-14s %-10s %-11s %s\n", "intrare", "automat", "go/scanner", "strconv")
+	for i, d := range representatives {
+		if i == examples {
+			fmt.Printf("  ... încă %d tipare\n", len(representatives)-examples)
+			break
+		}
+		fmt.Printf("  %-14q %-10s %-11s %s\n", d.input, verdictName(d.automaton), verdictName(d.scanner), verdictName(d.strconv))
+	}
+	fmt.Println()
+}
+
+// shape maps characters that play the same role in a literal onto one
+// representative: letter case, the digits after the first character and the
+// hexadecimal letters that are not also prefixes or exponents.
+func shape(s string) string {
+	runes := []rune(strings.ToLower(s))
+	for i, r := range runes {
+		switch {
+		case i > 0 && r >= '0' && r <= '9':
+			runes[i] = 'd'
+		case r == 'c' || r == 'd' || r == 'f':
+			runes[i] = 'a'
+		}
+	}
+	return string(runes)
+}
+
+func verdictName(accepted bool) string {
+	if accepted {
+		return "acceptă"
+	}
+	return "respinge"
+}
+
+func containsString(slice []string, item string) bool {
+	for _, s := range slice {
+		if s == item {
+			return true
+		}
+	}
+	return false
+}
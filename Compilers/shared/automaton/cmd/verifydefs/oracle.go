@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"go/scanner"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// scanLiteral reports whether go/scanner reads s as exactly one token of the
+// given kind, without errors. A keyword counts as an identifier, since the
+// identifier automaton does not exclude keywords (the lexer looks them up).
+func scanLiteral(s string, kind token.Token) bool {
+	if s == "" {
+		return false
+	}
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(s))
+
+	errorCount := 0
+	var sc scanner.Scanner
+	sc.Init(file, []byte(s), func(token.Position, string) { errorCount++ }, 0)
+
+	_, tok, lit := sc.Scan()
+	_, next, nextLit := sc.Scan()
+	if errorCount > 0 || lit != s {
+		return false
+	}
+	if next != token.EOF && !(next == token.SEMICOLON && nextLit == "\n") {
+		return false
+	}
+
+	if kind == token.IDENT {
+		return tok == token.IDENT || tok.IsKeyword()
+	}
+	return tok == kind
+}
+
+// strconvVerdict is a second opinion from strconv. It is more lenient than
+// the spec (ParseFloat accepts "08" or "1"), so it is only shown next to the
+// examples, while go/scanner decides what a disagreement is.
+func strconvVerdict(definition, s string) bool {
+	switch definition {
+	case "integer":
+		_, err := strconv.ParseInt(s, 0, 64)
+		return err == nil || errors.Is(err, strconv.ErrRange)
+	case "float":
+		lower := strings.ToLower(s)
+		if strings.Contains(lower, "inf") || strings.Contains(lower, "nan") {
+			return false
+		}
+		if _, err := strconv.ParseInt(s, 0, 64); err == nil || errors.Is(err, strconv.ErrRange) {
+			return false
+		}
+		_, err := strconv.ParseFloat(s, 64)
+		return err == nil || errors.Is(err, strconv.ErrRange)
+	default:
+		return token.IsIdentifier(s) || token.Lookup(s).IsKeyword()
+	}
+}
+
+func goVerdict(definition, s string) bool {
+	switch definition {
+	case "integer":
+		return scanLiteral(s, token.INT)
+	case "float":
+		return scanLiteral(s, token.FLOAT)
+	default:
+		return scanLiteral(s, token.IDENT)
+	}
+}
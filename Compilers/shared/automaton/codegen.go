@@ -0,0 +1,386 @@
+package automaton
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	GoStyleSwitch = "switch"
+	GoStyleTable  = "table"
+)
+
+// GoOptions configures GenerateGo. Name is appended to the generated
+// identifiers (MatchName, LongestPrefixName), so several matchers can live in
+// one package.
+type GoOptions struct {
+	Package string
+	Name    string
+	Style   string // GoStyleSwitch (default) or GoStyleTable
+	Source  string // mentioned in the "Code generated" header
+}
+
+// GenerateGo emits dependency-free Go source for a deterministic automaton
+// whose symbols are single characters. The code defines
+//
+//	func Match<Name>(s string) bool
+//	func LongestPrefix<Name>(s string) int
+//
+// where LongestPrefix returns the length in bytes of the longest accepted
+// prefix, or -1 when no prefix is accepted. States are numbered in the
+// canonical order (see Canonicalize).
+func GenerateGo(fa *FiniteAutomaton, opts GoOptions) (string, error) {
+	if !fa.IsDeterministic() {
+		return "", fmt.Errorf("generarea de cod necesită un automat determinist")
+	}
+	for _, symbol := range fa.Alphabet {
+		if utf8.RuneCountInString(symbol) != 1 {
+			return "", fmt.Errorf("simbolul '%s' trebuie să fie un singur caracter", symbol)
+		}
+	}
+
+	if opts.Package == "" {
+		opts.Package = "main"
+	}
+	if opts.Style == "" {
+		opts.Style = GoStyleSwitch
+	}
+	if opts.Style != GoStyleSwitch && opts.Style != GoStyleTable {
+		return "", fmt.Errorf("stilul '%s' nu este cunoscut (se așteaptă %s sau %s)", opts.Style, GoStyleSwitch, GoStyleTable)
+	}
+
+	g := &goGenerator{fa: fa, opts: opts, title: fa.Name, initial: fa.InitialState, isFinal: fa.IsFinalState}
+	g.order, g.index = fa.canonicalStateIndexes()
+
+	g.header()
+	if opts.Style == GoStyleTable {
+		g.table()
+	} else {
+		g.switchStep()
+	}
+	g.accepting()
+	g.api()
+	return g.source()
+}
+
+// GenerateSymbolicGo emits the same API as GenerateGo for a deterministic
+// symbolic automaton. Every guard becomes a sorted table of rune ranges
+// searched by bisection, so the generated code still needs no imports.
+// States keep the order of States; only GoStyleSwitch is supported.
+func GenerateSymbolicGo(sa *SymbolicAutomaton, opts GoOptions) (string, error) {
+	if err := sa.Validate(); err != nil {
+		return "", fmt.Errorf("automat invalid: %v", err)
+	}
+	if !sa.IsDeterministic() {
+		return "", fmt.Errorf("generarea de cod necesită un automat determinist")
+	}
+
+	if opts.Package == "" {
+		opts.Package = "main"
+	}
+	if opts.Style == "" {
+		opts.Style = GoStyleSwitch
+	}
+	if opts.Style != GoStyleSwitch {
+		return "", fmt.Errorf("automatele simbolice se generează doar în stilul %s", GoStyleSwitch)
+	}
+
+	g := &goGenerator{opts: opts, title: sa.Name, initial: sa.InitialState, isFinal: sa.IsFinalState}
+	g.order = sa.States
+	g.index = make(map[string]int)
+	for i, state := range sa.States {
+		g.index[state] = i
+	}
+
+	g.header()
+	g.symbolicStep(sa)
+	g.accepting()
+	g.api()
+	return g.source()
+}
+
+func (fa *FiniteAutomaton) canonicalStateIndexes() ([]string, map[string]int) {
+	order, _ := fa.canonicalOrder()
+	index := make(map[string]int)
+	for i, state := range order {
+		index[state] = i
+	}
+	return order, index
+}
+
+type goGenerator struct {
+	fa      *FiniteAutomaton // nil for a symbolic automaton
+	opts    GoOptions
+	title   string
+	initial string
+	isFinal func(state string) bool
+	order   []string
+	index   map[string]int
+	sb      strings.Builder
+}
+
+func (g *goGenerator) source() (string, error) {
+	formatted, err := format.Source([]byte(g.sb.String()))
+	if err != nil {
+		return "", fmt.Errorf("codul generat nu este valid: %v", err)
+	}
+	return string(formatted), nil
+}
+
+func (g *goGenerator) printf(format string, args ...interface{}) {
+	g.sb.WriteString(fmt.Sprintf(format, args...))
+}
+
+func (g *goGenerator) ident(base string) string {
+	return base + g.opts.Name
+}
+
+func (g *goGenerator) helper(base string) string {
+	return strings.ToLower(base[:1]) + base[1:] + g.opts.Name
+}
+
+func (g *goGenerator) header() {
+	source := ""
+	if g.opts.Source != "" {
+		source = " from " + g.opts.Source
+	}
+	g.printf("// Code generated by fa2go%s; DO NOT EDIT.\n\n", source)
+	g.printf("package %s\n\n", g.opts.Package)
+
+	g.printf("// States of the %s matcher:\n", g.describe())
+	for i, state := range g.order {
+		g.printf("//\t%d: %s\n", i, state)
+	}
+	g.printf("\n")
+}
+
+func (g *goGenerator) describe() string {
+	if g.title != "" {
+		return strconv.Quote(g.title)
+	}
+	if g.opts.Name != "" {
+		return g.opts.Name
+	}
+	return "generated"
+}
+
+// targets groups the symbols of a state by target state, in symbol order.
+func (g *goGenerator) targets(state string) ([]int, map[int][]rune) {
+	byTarget := make(map[int][]rune)
+	for symbol, next := range g.fa.Transitions[state] {
+		if len(next) == 0 {
+			continue
+		}
+		r, _ := utf8.DecodeRuneInString(symbol)
+		byTarget[g.index[next[0]]] = append(byTarget[g.index[next[0]]], r)
+	}
+
+	targets := make([]int, 0, len(byTarget))
+	for target, runes := range byTarget {
+		sort.Slice(runes, func(i, j int) bool { return runes[i] < runes[j] })
+		targets = append(targets, target)
+	}
+	sort.Ints(targets)
+	return targets, byTarget
+}
+
+func (g *goGenerator) switchStep() {
+	g.printf("// %s returns the next state, or -1 when there is no transition.\n", g.helper("Step"))
+	g.printf("func %s(state int, r rune) int {\n", g.helper("Step"))
+	g.printf("switch state {\n")
+	for i, state := range g.order {
+		targets, byTarget := g.targets(state)
+		if len(targets) == 0 {
+			continue
+		}
+		g.printf("case %d:\n", i)
+		g.printf("switch r {\n")
+		for _, target := range targets {
+			quoted := make([]string, 0, len(byTarget[target]))
+			for _, r := range byTarget[target] {
+				quoted = append(quoted, strconv.QuoteRune(r))
+			}
+			g.printf("case %s:\n", strings.Join(quoted, ", "))
+			g.printf("return %d\n", target)
+		}
+		g.printf("}\n")
+	}
+	g.printf("}\n")
+	g.printf("return -1\n")
+	g.printf("}\n\n")
+}
+
+func (g *goGenerator) table() {
+	symbols := make([]rune, 0, len(g.fa.Alphabet))
+	class := make(map[rune]int)
+	for _, symbol := range g.fa.Alphabet {
+		r, _ := utf8.DecodeRuneInString(symbol)
+		if _, exists := class[r]; !exists {
+			class[r] = len(symbols)
+			symbols = append(symbols, r)
+		}
+	}
+
+	g.printf("// %s maps every symbol to its column in %s.\n", g.helper("Classes"), g.helper("Table"))
+	g.printf("var %s = map[rune]int{\n", g.helper("Classes"))
+	for i, r := range symbols {
+		g.printf("%s: %d,\n", strconv.QuoteRune(r), i)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %s[state][class] is the next state, or -1.\n", g.helper("Table"))
+	g.printf("var %s = [][]int{\n", g.helper("Table"))
+	for i, state := range g.order {
+		row := make([]string, len(symbols))
+		for j := range row {
+			row[j] = "-1"
+		}
+		for symbol, next := range g.fa.Transitions[state] {
+			if len(next) == 0 {
+				continue
+			}
+			r, _ := utf8.DecodeRuneInString(symbol)
+			row[class[r]] = strconv.Itoa(g.index[next[0]])
+		}
+		g.printf("{%s}, // %d\n", strings.Join(row, ", "), i)
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %s returns the next state, or -1 when there is no transition.\n", g.helper("Step"))
+	g.printf("func %s(state int, r rune) int {\n", g.helper("Step"))
+	g.printf("class, exists := %s[r]\n", g.helper("Classes"))
+	g.printf("if !exists {\n")
+	g.printf("return -1\n")
+	g.printf("}\n")
+	g.printf("return %s[state][class]\n", g.helper("Table"))
+	g.printf("}\n\n")
+}
+
+// symbolicStep writes one range table per guard and a step function that
+// tries the guards of the current state in turn.
+func (g *goGenerator) symbolicStep(sa *SymbolicAutomaton) {
+	g.printf("// %s reports whether r lies in one of the sorted, disjoint ranges.\n", g.helper("InRanges"))
+	g.printf("func %s(r rune, ranges [][2]rune) bool {\n", g.helper("InRanges"))
+	g.printf("lo, hi := 0, len(ranges)\n")
+	g.printf("for lo < hi {\n")
+	g.printf("mid := (lo + hi) / 2\n")
+	g.printf("switch {\n")
+	g.printf("case r < ranges[mid][0]:\n")
+	g.printf("hi = mid\n")
+	g.printf("case r > ranges[mid][1]:\n")
+	g.printf("lo = mid + 1\n")
+	g.printf("default:\n")
+	g.printf("return true\n")
+	g.printf("}\n")
+	g.printf("}\n")
+	g.printf("return false\n")
+	g.printf("}\n\n")
+
+	tables := make(map[string]string)
+	for _, state := range g.order {
+		for _, move := range sa.Transitions[state] {
+			if _, exists := tables[move.Guard]; exists || sa.guard(move).IsEmpty() {
+				continue
+			}
+			name := fmt.Sprintf("%s%d", g.helper("Guard"), len(tables))
+			tables[move.Guard] = name
+
+			g.printf("// %s is %s.\n", name, move.Guard)
+			g.printf("var %s = [][2]rune{\n", name)
+			ranges := sa.guard(move).Ranges()
+			for i, r := range ranges {
+				g.printf("{%s, %s},", strconv.QuoteRune(r.Lo), strconv.QuoteRune(r.Hi))
+				if i%4 == 3 || i == len(ranges)-1 {
+					g.printf("\n")
+				} else {
+					g.printf(" ")
+				}
+			}
+			g.printf("}\n\n")
+		}
+	}
+
+	g.printf("// %s returns the next state, or -1 when there is no transition.\n", g.helper("Step"))
+	g.printf("func %s(state int, r rune) int {\n", g.helper("Step"))
+	g.printf("switch state {\n")
+	for i, state := range g.order {
+		moves := []SymbolicMove{}
+		for _, move := range sa.Transitions[state] {
+			if _, exists := tables[move.Guard]; exists {
+				moves = append(moves, move)
+			}
+		}
+		if len(moves) == 0 {
+			continue
+		}
+		g.printf("case %d:\n", i)
+		g.printf("switch {\n")
+		for _, move := range moves {
+			g.printf("case %s(r, %s):\n", g.helper("InRanges"), tables[move.Guard])
+			g.printf("return %d\n", g.index[move.To])
+		}
+		g.printf("}\n")
+	}
+	g.printf("}\n")
+	g.printf("return -1\n")
+	g.printf("}\n\n")
+}
+
+func (g *goGenerator) accepting() {
+	finals := []string{}
+	for i, state := range g.order {
+		if g.isFinal(state) {
+			finals = append(finals, strconv.Itoa(i))
+		}
+	}
+
+	g.printf("func %s(state int) bool {\n", g.helper("Accepting"))
+	if len(finals) == 0 {
+		g.printf("return false\n")
+	} else {
+		g.printf("switch state {\n")
+		g.printf("case %s:\n", strings.Join(finals, ", "))
+		g.printf("return true\n")
+		g.printf("}\n")
+		g.printf("return false\n")
+	}
+	g.printf("}\n\n")
+}
+
+func (g *goGenerator) api() {
+	initial := g.index[g.initial]
+
+	g.printf("// %s reports whether the whole of s is accepted.\n", g.ident("Match"))
+	g.printf("func %s(s string) bool {\n", g.ident("Match"))
+	g.printf("state := %d\n", initial)
+	g.printf("for _, r := range s {\n")
+	g.printf("if state = %s(state, r); state < 0 {\n", g.helper("Step"))
+	g.printf("return false\n")
+	g.printf("}\n")
+	g.printf("}\n")
+	g.printf("return %s(state)\n", g.helper("Accepting"))
+	g.printf("}\n\n")
+
+	g.printf("// %s returns the length in bytes of the longest accepted prefix of s,\n", g.ident("LongestPrefix"))
+	g.printf("// or -1 when no prefix is accepted.\n")
+	g.printf("func %s(s string) int {\n", g.ident("LongestPrefix"))
+	g.printf("longest := -1\n")
+	g.printf("state := %d\n", initial)
+	g.printf("if %s(state) {\n", g.helper("Accepting"))
+	g.printf("longest = 0\n")
+	g.printf("}\n")
+	g.printf("for i, r := range s {\n")
+	g.printf("if state = %s(state, r); state < 0 {\n", g.helper("Step"))
+	g.printf("break\n")
+	g.printf("}\n")
+	g.printf("if %s(state) {\n", g.helper("Accepting"))
+	g.printf("longest = i + len(string(r))\n")
+	g.printf("}\n")
+	g.printf("}\n")
+	g.printf("return longest\n")
+	g.printf("}\n")
+}
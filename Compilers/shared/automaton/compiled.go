@@ -0,0 +1,397 @@
+package automaton
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"sort"
+	"unicode/utf8"
+)
+
+// CompiledFormatVersion is written after the magic of every compiled file;
+// files of other versions are rejected.
+const CompiledFormatVersion = 1
+
+var compiledMagic = []byte("FADF")
+
+// CompiledDFA is a deterministic automaton flattened for fast loading and
+// matching. Symbols with identical columns share a class, the transition
+// table has one row per state and one column per class, and states are
+// numbered in the canonical order (see Canonicalize), so state 0 is the
+// initial state.
+//
+// The binary format (MarshalBinary) is, with unsigned varints for numbers and
+// strings prefixed by their length:
+//
+//	"FADF" version:uint16
+//	name states:uvarint state names...
+//	classes:uvarint symbols:uvarint (symbol class)...
+//	table: states×classes entries, each next+1 (0 = no transition)
+//	accepting: bitset of ⌈states/8⌉ bytes
+//	crc32 (IEEE) of everything before it:uint32
+//
+// All fixed-size numbers are little endian.
+type CompiledDFA struct {
+	Name      string
+	States    []string
+	Symbols   []rune // sorted
+	Classes   []int  // Classes[i] is the class of Symbols[i]
+	Table     []int  // Table[state*ClassCount()+class] is the next state, or -1
+	Accepting []bool
+
+	numClasses int
+	ascii      [utf8.RuneSelf]int // class+1 of ASCII runes, 0 if not a symbol
+	others     map[rune]int
+}
+
+// Compile flattens a deterministic automaton whose symbols are single
+// characters.
+func (fa *FiniteAutomaton) Compile() (*CompiledDFA, error) {
+	if !fa.IsDeterministic() {
+		return nil, fmt.Errorf("compilarea necesită un automat determinist")
+	}
+	for _, symbol := range fa.Alphabet {
+		if utf8.RuneCountInString(symbol) != 1 {
+			return nil, fmt.Errorf("simbolul '%s' trebuie să fie un singur caracter", symbol)
+		}
+	}
+
+	order, index := fa.canonicalStateIndexes()
+	alphabet := append([]string{}, fa.Alphabet...)
+	sort.Strings(alphabet)
+
+	// Two symbols share a class when every state sends them to the same
+	// target; the column is the key.
+	columnClass := make(map[string]int)
+	c := &CompiledDFA{Name: fa.Name, States: order}
+	columns := [][]int{}
+	for i, symbol := range alphabet {
+		if i > 0 && symbol == alphabet[i-1] {
+			continue
+		}
+		column := make([]int, len(order))
+		for j, state := range order {
+			column[j] = -1
+			if next := fa.Transitions[state][symbol]; len(next) > 0 {
+				column[j] = index[next[0]]
+			}
+		}
+		key := fmt.Sprint(column)
+		class, exists := columnClass[key]
+		if !exists {
+			class = len(columns)
+			columnClass[key] = class
+			columns = append(columns, column)
+		}
+		r, _ := utf8.DecodeRuneInString(symbol)
+		c.Symbols = append(c.Symbols, r)
+		c.Classes = append(c.Classes, class)
+	}
+
+	c.numClasses = len(columns)
+	c.Table = make([]int, len(order)*c.numClasses)
+	for class, column := range columns {
+		for state, next := range column {
+			c.Table[state*c.numClasses+class] = next
+		}
+	}
+	c.Accepting = make([]bool, len(order))
+	for i, state := range order {
+		c.Accepting[i] = fa.IsFinalState(state)
+	}
+
+	c.index()
+	return c, nil
+}
+
+// index builds the rune lookup used by Step.
+func (c *CompiledDFA) index() {
+	c.ascii = [utf8.RuneSelf]int{}
+	c.others = make(map[rune]int)
+	for i, r := range c.Symbols {
+		if r < utf8.RuneSelf {
+			c.ascii[r] = c.Classes[i] + 1
+		} else {
+			c.others[r] = c.Classes[i]
+		}
+	}
+}
+
+func (c *CompiledDFA) StateCount() int {
+	return len(c.States)
+}
+
+func (c *CompiledDFA) ClassCount() int {
+	return c.numClasses
+}
+
+// Step returns the state reached from state by reading r, or -1.
+func (c *CompiledDFA) Step(state int, r rune) int {
+	class := -1
+	if r >= 0 && r < utf8.RuneSelf {
+		class = c.ascii[r] - 1
+	} else if found, exists := c.others[r]; exists {
+		class = found
+	}
+	if class < 0 {
+		return -1
+	}
+	return c.Table[state*c.numClasses+class]
+}
+
+func (c *CompiledDFA) Accepts(input string) bool {
+	if len(c.States) == 0 {
+		return false
+	}
+	state := 0
+	for _, r := range input {
+		if state = c.Step(state, r); state < 0 {
+			return false
+		}
+	}
+	return c.Accepting[state]
+}
+
+// LongestPrefix returns the length in bytes of the longest prefix of input
+// the automaton accepts, or -1 when there is none, like the functions
+// generated by GenerateGo.
+func (c *CompiledDFA) LongestPrefix(input string) int {
+	if len(c.States) == 0 {
+		return -1
+	}
+	longest, state := -1, 0
+	for offset, r := range input {
+		if c.Accepting[state] {
+			longest = offset
+		}
+		if state = c.Step(state, r); state < 0 {
+			return longest
+		}
+	}
+	if c.Accepting[state] {
+		longest = len(input)
+	}
+	return longest
+}
+
+// Automaton expands the compiled form back into a FiniteAutomaton with the
+// original state names.
+func (c *CompiledDFA) Automaton() *FiniteAutomaton {
+	fa := &FiniteAutomaton{
+		Version:     FormatVersion,
+		Name:        c.Name,
+		States:      append([]string{}, c.States...),
+		Alphabet:    []string{},
+		Transitions: make(map[string]map[string][]string),
+		FinalStates: []string{},
+	}
+	if len(c.States) > 0 {
+		fa.InitialState = c.States[0]
+	}
+	for i, state := range c.States {
+		if c.Accepting[i] {
+			fa.FinalStates = append(fa.FinalStates, state)
+		}
+	}
+	for i, r := range c.Symbols {
+		symbol := string(r)
+		fa.Alphabet = append(fa.Alphabet, symbol)
+		for state, from := range c.States {
+			next := c.Table[state*c.numClasses+c.Classes[i]]
+			if next < 0 {
+				continue
+			}
+			if fa.Transitions[from] == nil {
+				fa.Transitions[from] = make(map[string][]string)
+			}
+			fa.Transitions[from][symbol] = []string{c.States[next]}
+		}
+	}
+	return fa
+}
+
+func (c *CompiledDFA) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Write(compiledMagic)
+	buf.Write(binary.LittleEndian.AppendUint16(nil, CompiledFormatVersion))
+
+	putUint := func(n int) { buf.Write(binary.AppendUvarint(nil, uint64(n))) }
+	putString := func(s string) {
+		putUint(len(s))
+		buf.WriteString(s)
+	}
+
+	putString(c.Name)
+	putUint(len(c.States))
+	for _, state := range c.States {
+		putString(state)
+	}
+	putUint(c.numClasses)
+	putUint(len(c.Symbols))
+	for i, r := range c.Symbols {
+		putUint(int(r))
+		putUint(c.Classes[i])
+	}
+	for _, next := range c.Table {
+		putUint(next + 1)
+	}
+	bits := make([]byte, (len(c.States)+7)/8)
+	for i, accepting := range c.Accepting {
+		if accepting {
+			bits[i/8] |= 1 << (i % 8)
+		}
+	}
+	buf.Write(bits)
+
+	buf.Write(binary.LittleEndian.AppendUint32(nil, crc32.ChecksumIEEE(buf.Bytes())))
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary reads the format written by MarshalBinary, checking the
+// magic, the version, the checksum and that every number is in range.
+func (c *CompiledDFA) UnmarshalBinary(data []byte) error {
+	header := len(compiledMagic) + 2
+	if len(data) < header+4 || !bytes.Equal(data[:len(compiledMagic)], compiledMagic) {
+		return fmt.Errorf("fișierul nu conține un automat compilat")
+	}
+	if version := binary.LittleEndian.Uint16(data[len(compiledMagic):]); version != CompiledFormatVersion {
+		return fmt.Errorf("versiunea %d a formatului compilat nu este suportată (se așteaptă %d)", version, CompiledFormatVersion)
+	}
+	body, sum := data[:len(data)-4], binary.LittleEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return fmt.Errorf("suma de control nu corespunde: automatul compilat este corupt")
+	}
+
+	r := &compiledReader{data: body, offset: header}
+	name := r.string()
+	states := make([]string, r.count(len(body)))
+	for i := range states {
+		states[i] = r.string()
+	}
+	numClasses := r.count(len(body))
+	symbols := make([]rune, r.count(len(body)))
+	classes := make([]int, len(symbols))
+	for i := range symbols {
+		symbols[i] = rune(r.number(utf8.MaxRune + 1))
+		classes[i] = r.number(numClasses)
+		if i > 0 && symbols[i] <= symbols[i-1] {
+			r.fail("simbolurile nu sunt sortate")
+		}
+	}
+	if r.err == nil && len(states)*numClasses > len(body) {
+		r.fail("tabelul de tranziții depășește fișierul")
+	}
+	table := []int{}
+	if r.err == nil {
+		table = make([]int, len(states)*numClasses)
+		for i := range table {
+			table[i] = r.number(len(states)+1) - 1
+		}
+	}
+	bits := r.bytes((len(states) + 7) / 8)
+	if r.err == nil && r.offset != len(body) {
+		r.fail("date în plus după mulțimea stărilor finale")
+	}
+	if r.err != nil {
+		return fmt.Errorf("automat compilat invalid: %v", r.err)
+	}
+
+	accepting := make([]bool, len(states))
+	for i := range accepting {
+		accepting[i] = bits[i/8]&(1<<(i%8)) != 0
+	}
+
+	*c = CompiledDFA{
+		Name:       name,
+		States:     states,
+		Symbols:    symbols,
+		Classes:    classes,
+		Table:      table,
+		Accepting:  accepting,
+		numClasses: numClasses,
+	}
+	c.index()
+	return nil
+}
+
+// compiledReader decodes the body of a compiled file; after the first error
+// every read returns zero values and err keeps the error.
+type compiledReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (r *compiledReader) fail(message string) {
+	if r.err == nil {
+		r.err = fmt.Errorf("%s (octetul %d)", message, r.offset)
+	}
+}
+
+// number reads a varint that must be below limit.
+func (r *compiledReader) number(limit int) int {
+	if r.err != nil {
+		return 0
+	}
+	n, size := binary.Uvarint(r.data[r.offset:])
+	if size <= 0 {
+		r.fail("număr invalid")
+		return 0
+	}
+	if n >= uint64(limit) {
+		r.fail(fmt.Sprintf("valoarea %d depășește limita %d", n, limit))
+		return 0
+	}
+	r.offset += size
+	return int(n)
+}
+
+// count reads a length, which can never exceed the size of the file.
+func (r *compiledReader) count(size int) int {
+	return r.number(size + 1)
+}
+
+func (r *compiledReader) bytes(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if r.offset+n > len(r.data) {
+		r.fail("fișierul se termină prea devreme")
+		return make([]byte, n)
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *compiledReader) string() string {
+	return string(r.bytes(r.count(len(r.data))))
+}
+
+// Save writes the compiled automaton in the binary format.
+func (c *CompiledDFA) Save(filename string) error {
+	data, err := c.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return fmt.Errorf("eroare la scrierea fișierului: %v", err)
+	}
+	return nil
+}
+
+// LoadCompiled reads a file written by Save. Nothing is re-validated beyond
+// the checks of UnmarshalBinary, which is what makes loading fast.
+func LoadCompiled(filename string) (*CompiledDFA, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+	c := &CompiledDFA{}
+	if err := c.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,551 @@
+package automaton
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	ConstructionPop        = "pop"
+	ConstructionNewState   = "new_state"
+	ConstructionTransition = "transition"
+	ConstructionFinal      = "final"
+	ConstructionClosure    = "closure"
+	ConstructionRound      = "round"
+	ConstructionSplit      = "split"
+	ConstructionRemove     = "remove"
+	ConstructionDone       = "done"
+)
+
+// ConstructionStep is one step of a construction, meant to be shown or
+// animated one at a time like the steps of a simulation. Which fields are set
+// depends on Action: State, Symbol and Target describe a state or transition
+// of the result, Members the original states a new state stands for,
+// Worklist the states still waiting to be processed and Partition the blocks
+// after a refinement round.
+type ConstructionStep struct {
+	Action    string     `json:"action"`
+	Message   string     `json:"message"`
+	State     string     `json:"state,omitempty"`
+	Symbol    string     `json:"symbol,omitempty"`
+	Target    string     `json:"target,omitempty"`
+	Members   []string   `json:"members,omitempty"`
+	Worklist  []string   `json:"worklist,omitempty"`
+	Partition [][]string `json:"partition,omitempty"`
+	Round     int        `json:"round,omitempty"`
+}
+
+// ConstructionTrace records the steps of one construction.
+type ConstructionTrace struct {
+	Algorithm string             `json:"algorithm"`
+	Steps     []ConstructionStep `json:"steps"`
+}
+
+// add records step; a nil trace records nothing, so the untraced versions
+// share the code of the traced ones.
+func (t *ConstructionTrace) add(step ConstructionStep) {
+	if t != nil {
+		t.Steps = append(t.Steps, step)
+	}
+}
+
+// String prints the steps numbered, one per line.
+func (t *ConstructionTrace) String() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("=== %s ===\n", t.Algorithm))
+	for i, step := range t.Steps {
+		sb.WriteString(fmt.Sprintf("%3d. %s\n", i+1, step.Message))
+	}
+	return sb.String()
+}
+
+// setName names the state standing for a set of states, e.g. "{q0,q1}".
+func setName(states []string) string {
+	if len(states) == 0 {
+		return "∅"
+	}
+	return "{" + strings.Join(states, ",") + "}"
+}
+
+func partitionString(blocks [][]string) string {
+	names := make([]string, len(blocks))
+	for i, block := range blocks {
+		names[i] = setName(block)
+	}
+	return strings.Join(names, " ")
+}
+
+// stateIndexes maps every state to its position in States, used to keep the
+// members of a set in a fixed order.
+func (fa *FiniteAutomaton) stateIndexes() map[string]int {
+	index := make(map[string]int, len(fa.States))
+	for i, state := range fa.States {
+		index[state] = i
+	}
+	return index
+}
+
+// epsilonClosure returns the states reachable from states through
+// ε-transitions alone, in the order of States.
+func (fa *FiniteAutomaton) epsilonClosure(states []string, index map[string]int) []string {
+	closure := []string{}
+	seen := make(map[string]bool)
+	for _, state := range states {
+		if !seen[state] {
+			seen[state] = true
+			closure = append(closure, state)
+		}
+	}
+	for i := 0; i < len(closure); i++ {
+		for _, next := range fa.Transitions[closure[i]][Epsilon] {
+			if !seen[next] {
+				seen[next] = true
+				closure = append(closure, next)
+			}
+		}
+	}
+	sort.SliceStable(closure, func(i, j int) bool {
+		return index[closure[i]] < index[closure[j]]
+	})
+	return closure
+}
+
+// Determinize builds the equivalent DFA by the subset construction.
+func Determinize(fa *FiniteAutomaton) *FiniteAutomaton {
+	return determinize(fa, nil)
+}
+
+// DeterminizeWithTrace is Determinize together with the steps of the
+// construction: every set taken from the worklist, every new set discovered
+// and every transition added.
+func DeterminizeWithTrace(fa *FiniteAutomaton) (*FiniteAutomaton, *ConstructionTrace) {
+	trace := &ConstructionTrace{Algorithm: "Construcția submulțimilor"}
+	return determinize(fa, trace), trace
+}
+
+// determinize starts from the ε-closure of the initial state and, for every
+// set taken from the worklist and every symbol, adds the ε-closure of the
+// states reached by that symbol. A state of the result is named after the
+// set it stands for, "{q0,q1}", and is final when the set contains a final
+// state. Only reachable sets are created and the empty set is left out, so
+// the result may be partial.
+func determinize(fa *FiniteAutomaton, trace *ConstructionTrace) *FiniteAutomaton {
+	index := fa.stateIndexes()
+	start := fa.epsilonClosure([]string{fa.InitialState}, index)
+
+	result := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     append([]string{}, fa.Alphabet...),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: setName(start),
+		FinalStates:  []string{},
+	}
+
+	message := fmt.Sprintf("Starea inițială este %s", setName(start))
+	if len(start) > 1 {
+		message = fmt.Sprintf("Starea inițială este %s, ε-închiderea lui %s", setName(start), fa.InitialState)
+	}
+	trace.add(ConstructionStep{Action: ConstructionNewState, Message: message, State: setName(start), Members: start})
+
+	seen := map[string]bool{setName(start): true}
+	worklist := [][]string{start}
+	for len(worklist) > 0 {
+		current := worklist[0]
+		worklist = worklist[1:]
+		name := setName(current)
+
+		waiting := make([]string, len(worklist))
+		for i, set := range worklist {
+			waiting[i] = setName(set)
+		}
+		trace.add(ConstructionStep{
+			Action:   ConstructionPop,
+			Message:  fmt.Sprintf("Se prelucrează %s (rămase: %d)", name, len(worklist)),
+			State:    name,
+			Members:  current,
+			Worklist: waiting,
+		})
+
+		result.States = append(result.States, name)
+		final := false
+		for _, state := range current {
+			final = final || fa.IsFinalState(state)
+		}
+		if final {
+			result.FinalStates = append(result.FinalStates, name)
+			trace.add(ConstructionStep{
+				Action:  ConstructionFinal,
+				Message: fmt.Sprintf("%s este finală, conține o stare finală", name),
+				State:   name,
+			})
+		}
+
+		for _, symbol := range fa.Alphabet {
+			moved := []string{}
+			for _, state := range current {
+				for _, next := range fa.Transitions[state][symbol] {
+					if !contains(moved, next) {
+						moved = append(moved, next)
+					}
+				}
+			}
+			if len(moved) == 0 {
+				continue
+			}
+
+			target := fa.epsilonClosure(moved, index)
+			targetName := setName(target)
+			if !seen[targetName] {
+				seen[targetName] = true
+				worklist = append(worklist, target)
+				trace.add(ConstructionStep{
+					Action:  ConstructionNewState,
+					Message: fmt.Sprintf("Stare nouă %s, adăugată în lista de lucru", targetName),
+					State:   targetName,
+					Members: target,
+				})
+			}
+			addTransitionUnchecked(result, name, symbol, targetName)
+			trace.add(ConstructionStep{
+				Action:  ConstructionTransition,
+				Message: fmt.Sprintf("δ(%s, %s) = %s", name, symbol, targetName),
+				State:   name,
+				Symbol:  symbol,
+				Target:  targetName,
+			})
+		}
+	}
+
+	trace.add(ConstructionStep{
+		Action:  ConstructionDone,
+		Message: fmt.Sprintf("Lista de lucru este goală: %d stări", len(result.States)),
+	})
+	return result
+}
+
+// Minimize builds the minimal DFA of a deterministic automaton.
+func Minimize(fa *FiniteAutomaton) (*FiniteAutomaton, error) {
+	return minimize(fa, nil)
+}
+
+// MinimizeWithTrace is Minimize together with the steps of the construction:
+// the states removed or added, the partition after every refinement round and
+// every block that splits.
+func MinimizeWithTrace(fa *FiniteAutomaton) (*FiniteAutomaton, *ConstructionTrace, error) {
+	trace := &ConstructionTrace{Algorithm: "Minimizare (rafinarea partițiilor)"}
+	result, err := minimize(fa, trace)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, trace, nil
+}
+
+// minimize removes the unreachable states, completes the automaton with a
+// sink state and refines the partition {F, Q\F} round by round (Moore's
+// algorithm): two states stay together while every symbol leads them into the
+// same block. The blocks of the final partition are the states of the result;
+// a block of several states is named after them, "{q1,q2}", and the block of
+// states that cannot reach a final state is dropped again, unless it holds
+// the initial state.
+func minimize(fa *FiniteAutomaton, trace *ConstructionTrace) (*FiniteAutomaton, error) {
+	if !fa.IsDeterministic() {
+		return nil, fmt.Errorf("minimizarea necesită un automat determinist; aplicați mai întâi construcția submulțimilor")
+	}
+
+	reachable := make(map[string]bool)
+	for _, state := range fa.reachableStates(fa.InitialState) {
+		reachable[state] = true
+	}
+	states := []string{}
+	for _, state := range fa.States {
+		if reachable[state] {
+			states = append(states, state)
+			continue
+		}
+		trace.add(ConstructionStep{
+			Action:  ConstructionRemove,
+			Message: fmt.Sprintf("Starea %s este inaccesibilă și se elimină", state),
+			State:   state,
+		})
+	}
+
+	used := make(map[string]bool)
+	for _, state := range fa.States {
+		used[state] = true
+	}
+	sink := ""
+	next := func(state, symbol string) string {
+		if targets := fa.Transitions[state][symbol]; len(targets) > 0 {
+			return targets[0]
+		}
+		return sink
+	}
+	for _, state := range states {
+		for _, symbol := range fa.Alphabet {
+			if sink == "" && len(fa.Transitions[state][symbol]) == 0 {
+				sink = freshName("∅", used)
+				trace.add(ConstructionStep{
+					Action:  ConstructionNewState,
+					Message: fmt.Sprintf("Se adaugă starea capcană %s pentru tranzițiile lipsă", sink),
+					State:   sink,
+				})
+			}
+		}
+	}
+	if sink != "" {
+		states = append(states, sink)
+	}
+
+	finals, others := []string{}, []string{}
+	for _, state := range states {
+		if fa.IsFinalState(state) {
+			finals = append(finals, state)
+		} else {
+			others = append(others, state)
+		}
+	}
+	blocks := [][]string{}
+	for _, block := range [][]string{finals, others} {
+		if len(block) > 0 {
+			blocks = append(blocks, block)
+		}
+	}
+	trace.add(ConstructionStep{
+		Action:    ConstructionRound,
+		Message:   fmt.Sprintf("Runda 0: stări finale și nefinale: %s", partitionString(blocks)),
+		Partition: copyPartition(blocks),
+	})
+
+	blockOf := func(blocks [][]string) map[string]int {
+		of := make(map[string]int)
+		for i, block := range blocks {
+			for _, state := range block {
+				of[state] = i
+			}
+		}
+		return of
+	}
+
+	for round := 1; ; round++ {
+		of := blockOf(blocks)
+		refined := [][]string{}
+		for _, block := range blocks {
+			signatures := []string{}
+			pieces := make(map[string][]string)
+			for _, state := range block {
+				key := make([]string, len(fa.Alphabet))
+				for i, symbol := range fa.Alphabet {
+					key[i] = fmt.Sprint(of[next(state, symbol)])
+				}
+				signature := strings.Join(key, ",")
+				if _, exists := pieces[signature]; !exists {
+					signatures = append(signatures, signature)
+				}
+				pieces[signature] = append(pieces[signature], state)
+			}
+
+			split := make([][]string, len(signatures))
+			for i, signature := range signatures {
+				split[i] = pieces[signature]
+			}
+			refined = append(refined, split...)
+
+			if len(split) > 1 {
+				first, second := split[0][0], split[1][0]
+				symbol := ""
+				for _, s := range fa.Alphabet {
+					if of[next(first, s)] != of[next(second, s)] {
+						symbol = s
+						break
+					}
+				}
+				trace.add(ConstructionStep{
+					Action: ConstructionSplit,
+					Message: fmt.Sprintf("Blocul %s se împarte în %s: %s și %s duc prin '%s' în blocuri diferite",
+						setName(block), partitionString(split), first, second, symbol),
+					Symbol:    symbol,
+					Members:   block,
+					Partition: copyPartition(split),
+					Round:     round,
+				})
+			}
+		}
+
+		if len(refined) == len(blocks) {
+			trace.add(ConstructionStep{
+				Action:    ConstructionDone,
+				Message:   fmt.Sprintf("Runda %d nu mai împarte niciun bloc: %d clase de echivalență", round, len(blocks)),
+				Partition: copyPartition(blocks),
+				Round:     round,
+			})
+			break
+		}
+		blocks = refined
+		trace.add(ConstructionStep{
+			Action:    ConstructionRound,
+			Message:   fmt.Sprintf("Runda %d: %s", round, partitionString(blocks)),
+			Partition: copyPartition(blocks),
+			Round:     round,
+		})
+	}
+
+	index := fa.stateIndexes()
+	if sink != "" {
+		index[sink] = len(fa.States)
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return index[blocks[i][0]] < index[blocks[j][0]]
+	})
+
+	of := blockOf(blocks)
+	names := make([]string, len(blocks))
+	for i, block := range blocks {
+		names[i] = block[0]
+		if len(block) > 1 {
+			names[i] = setName(block)
+		}
+	}
+
+	initial := of[fa.InitialState]
+	dead := -1
+	for i, block := range blocks {
+		if i == initial || fa.IsFinalState(block[0]) {
+			continue
+		}
+		loops := true
+		for _, symbol := range fa.Alphabet {
+			if of[next(block[0], symbol)] != i {
+				loops = false
+				break
+			}
+		}
+		if loops {
+			dead = i
+			trace.add(ConstructionStep{
+				Action:  ConstructionRemove,
+				Message: fmt.Sprintf("Blocul %s nu poate ajunge într-o stare finală și se elimină", names[i]),
+				State:   names[i],
+				Members: block,
+			})
+		}
+	}
+
+	result := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     append([]string{}, fa.Alphabet...),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: names[initial],
+		FinalStates:  []string{},
+	}
+	for i, block := range blocks {
+		if i == dead {
+			continue
+		}
+		result.States = append(result.States, names[i])
+		if fa.IsFinalState(block[0]) {
+			result.FinalStates = append(result.FinalStates, names[i])
+		}
+		for _, symbol := range fa.Alphabet {
+			if target := of[next(block[0], symbol)]; target != dead {
+				addTransitionUnchecked(result, names[i], symbol, names[target])
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func copyPartition(blocks [][]string) [][]string {
+	partition := make([][]string, len(blocks))
+	for i, block := range blocks {
+		partition[i] = append([]string{}, block...)
+	}
+	return partition
+}
+
+// RemoveEpsilon returns an equivalent automaton without ε-transitions, on the
+// same states: p reads a to r when some state q in the ε-closure of p reads a
+// to r, and p is final when its closure contains a final state.
+func (fa *FiniteAutomaton) RemoveEpsilon() *FiniteAutomaton {
+	return removeEpsilon(fa, nil)
+}
+
+// withoutEpsilon is fa itself when it has no ε-transitions and RemoveEpsilon
+// otherwise, for the algorithms that only follow symbols of the alphabet.
+func (fa *FiniteAutomaton) withoutEpsilon() *FiniteAutomaton {
+	if fa.HasEpsilonTransitions() {
+		return fa.RemoveEpsilon()
+	}
+	return fa
+}
+
+// RemoveEpsilonWithTrace is RemoveEpsilon together with the steps of the
+// construction: the ε-closure of every state and the transitions and final
+// states it contributes.
+func (fa *FiniteAutomaton) RemoveEpsilonWithTrace() (*FiniteAutomaton, *ConstructionTrace) {
+	trace := &ConstructionTrace{Algorithm: "Eliminarea ε-tranzițiilor"}
+	return removeEpsilon(fa, trace), trace
+}
+
+func removeEpsilon(fa *FiniteAutomaton, trace *ConstructionTrace) *FiniteAutomaton {
+	index := fa.stateIndexes()
+	result := &FiniteAutomaton{
+		States:       append([]string{}, fa.States...),
+		Alphabet:     append([]string{}, fa.Alphabet...),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: fa.InitialState,
+		FinalStates:  []string{},
+	}
+
+	for _, state := range fa.States {
+		closure := fa.epsilonClosure([]string{state}, index)
+		trace.add(ConstructionStep{
+			Action:  ConstructionClosure,
+			Message: fmt.Sprintf("ε-închiderea lui %s este %s", state, setName(closure)),
+			State:   state,
+			Members: closure,
+		})
+
+		for _, q := range closure {
+			if fa.IsFinalState(q) && !result.IsFinalState(state) {
+				result.FinalStates = append(result.FinalStates, state)
+				trace.add(ConstructionStep{
+					Action:  ConstructionFinal,
+					Message: fmt.Sprintf("%s devine finală: ajunge prin ε în %s", state, q),
+					State:   state,
+				})
+			}
+			for _, symbol := range fa.Alphabet {
+				for _, next := range fa.Transitions[q][symbol] {
+					if contains(result.Transitions[state][symbol], next) {
+						continue
+					}
+					addTransitionUnchecked(result, state, symbol, next)
+					message := fmt.Sprintf("δ'(%s, %s) ∋ %s", state, symbol, next)
+					if q != state {
+						message += fmt.Sprintf(", prin %s", q)
+					}
+					trace.add(ConstructionStep{
+						Action:  ConstructionTransition,
+						Message: message,
+						State:   state,
+						Symbol:  symbol,
+						Target:  next,
+					})
+				}
+			}
+		}
+	}
+
+	trace.add(ConstructionStep{Action: ConstructionDone, Message: "Toate ε-tranzițiile au fost eliminate"})
+	return result
+}
+
+// IntersectionWithTrace is Intersection together with the steps of the
+// product construction: every pair taken from the queue, every new pair
+// discovered and every transition added.
+func IntersectionWithTrace(a, b *FiniteAutomaton) (*FiniteAutomaton, *ConstructionTrace) {
+	trace := &ConstructionTrace{Algorithm: "Construcția produs (intersecție)"}
+	return intersection(a, b, trace), trace
+}
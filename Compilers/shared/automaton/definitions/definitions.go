@@ -0,0 +1,37 @@
+// Package definitions embeds the automata for Go literals described in
+// spec.md, so programs can load them without depending on the working
+// directory.
+package definitions
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/bujor/compilers/shared/automaton"
+)
+
+//go:embed *.json
+var files embed.FS
+
+// Load parses the definition with the given name, e.g. "integer". Symbolic
+// definitions ("type": "symbolic") are loaded with LoadSymbolic.
+func Load(name string) (*automaton.FiniteAutomaton, error) {
+	data, err := files.ReadFile(name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("definiția '%s' nu există", name)
+	}
+	if automaton.IsSymbolicJSON(string(data)) {
+		return nil, fmt.Errorf("definiția '%s' este un automat simbolic și se încarcă cu LoadSymbolic", name)
+	}
+	return automaton.ParseFromJSON(string(data))
+}
+
+// LoadSymbolic parses a definition whose transitions are rune predicates,
+// e.g. "identifier_unicode".
+func LoadSymbolic(name string) (*automaton.SymbolicAutomaton, error) {
+	data, err := files.ReadFile(name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("definiția '%s' nu există", name)
+	}
+	return automaton.ParseSymbolicFromJSON(string(data))
+}
@@ -0,0 +1,313 @@
+package automaton
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// PatternMatcher is an Aho–Corasick automaton. As a FiniteAutomaton it is a
+// complete DFA that accepts exactly the texts ending with one of the patterns;
+// Outputs lists the patterns recognised in each state, longest first.
+type PatternMatcher struct {
+	Automaton *FiniteAutomaton    `json:"automaton"`
+	Outputs   map[string][]string `json:"outputs"`
+}
+
+// PatternMatch is an occurrence of a pattern; Start and End are byte offsets.
+type PatternMatch struct {
+	Pattern string `json:"pattern"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+}
+
+// trieNode is the shared working representation of both builders.
+type trieNode struct {
+	children map[string]*trieNode
+	final    bool
+	last     string // most recently added child, used by the Daciuk construction
+	fail     *trieNode
+	outputs  []string
+	name     string
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[string]*trieNode)}
+}
+
+func (n *trieNode) sortedSymbols() []string {
+	symbols := make([]string, 0, len(n.children))
+	for symbol := range n.children {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func splitSymbols(word string) []string {
+	symbols := make([]string, 0, utf8.RuneCountInString(word))
+	for _, char := range word {
+		symbols = append(symbols, string(char))
+	}
+	return symbols
+}
+
+func wordAlphabet(words []string) []string {
+	symbolSet := make(map[string]bool)
+	for _, word := range words {
+		for _, char := range word {
+			symbolSet[string(char)] = true
+		}
+	}
+	alphabet := getKeys(symbolSet)
+	sort.Strings(alphabet)
+	return alphabet
+}
+
+// MinimalAcyclicDFA builds the minimal DFA recognising exactly the given words
+// with the incremental construction of Daciuk et al. for sorted input: after
+// each word, the part of the previous word that is no longer shared is
+// minimised against a register of already minimal states.
+func MinimalAcyclicDFA(words []string) (*FiniteAutomaton, error) {
+	if len(words) == 0 {
+		return nil, fmt.Errorf("lista de cuvinte este goală")
+	}
+
+	sorted := append([]string{}, words...)
+	sort.Strings(sorted)
+
+	alphabet := wordAlphabet(sorted)
+	if len(alphabet) == 0 {
+		return nil, fmt.Errorf("cuvintele trebuie să conțină cel puțin un simbol")
+	}
+
+	root := newTrieNode()
+	register := make(map[string]*trieNode)
+	ids := make(map[*trieNode]int)
+
+	signature := func(n *trieNode) string {
+		var sb strings.Builder
+		if n.final {
+			sb.WriteString("1")
+		} else {
+			sb.WriteString("0")
+		}
+		for _, symbol := range n.sortedSymbols() {
+			sb.WriteString(fmt.Sprintf("|%s:%d", symbol, ids[n.children[symbol]]))
+		}
+		return sb.String()
+	}
+
+	var replaceOrRegister func(n *trieNode)
+	replaceOrRegister = func(n *trieNode) {
+		child := n.children[n.last]
+		if len(child.children) > 0 {
+			replaceOrRegister(child)
+		}
+
+		key := signature(child)
+		if existing, exists := register[key]; exists {
+			n.children[n.last] = existing
+		} else {
+			ids[child] = len(ids) + 1
+			register[key] = child
+		}
+	}
+
+	for i, word := range sorted {
+		if i > 0 && word == sorted[i-1] {
+			continue
+		}
+
+		symbols := splitSymbols(word)
+		current := root
+		prefix := 0
+		for prefix < len(symbols) {
+			next, exists := current.children[symbols[prefix]]
+			if !exists {
+				break
+			}
+			current = next
+			prefix++
+		}
+
+		if len(current.children) > 0 {
+			replaceOrRegister(current)
+		}
+
+		for _, symbol := range symbols[prefix:] {
+			next := newTrieNode()
+			current.children[symbol] = next
+			current.last = symbol
+			current = next
+		}
+		current.final = true
+	}
+
+	if len(root.children) > 0 {
+		replaceOrRegister(root)
+	}
+
+	fa, _ := trieToAutomaton(root, alphabet, nil)
+	return fa, nil
+}
+
+// AhoCorasick builds a multi-pattern search automaton: the trie of the
+// patterns with failure links, compiled into a complete DFA.
+func AhoCorasick(patterns []string) (*PatternMatcher, error) {
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("lista de cuvinte este goală")
+	}
+
+	alphabet := wordAlphabet(patterns)
+	if len(alphabet) == 0 {
+		return nil, fmt.Errorf("modelele trebuie să conțină cel puțin un simbol")
+	}
+
+	root := newTrieNode()
+	for _, pattern := range patterns {
+		if pattern == "" {
+			return nil, fmt.Errorf("modelele nu pot fi cuvântul vid")
+		}
+
+		current := root
+		for _, symbol := range splitSymbols(pattern) {
+			next, exists := current.children[symbol]
+			if !exists {
+				next = newTrieNode()
+				current.children[symbol] = next
+			}
+			current = next
+		}
+		if !current.final {
+			current.final = true
+			current.outputs = []string{pattern}
+		}
+	}
+
+	// Failure links in breadth-first order; every node inherits the outputs
+	// of its failure target, which is strictly shorter.
+	root.fail = root
+	queue := []*trieNode{}
+	for _, symbol := range root.sortedSymbols() {
+		child := root.children[symbol]
+		child.fail = root
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		for _, symbol := range node.sortedSymbols() {
+			child := node.children[symbol]
+			fallback := node.fail
+			for fallback != root && fallback.children[symbol] == nil {
+				fallback = fallback.fail
+			}
+			if target, exists := fallback.children[symbol]; exists && target != child {
+				child.fail = target
+			} else {
+				child.fail = root
+			}
+			child.outputs = append(child.outputs, child.fail.outputs...)
+			if len(child.outputs) > 0 {
+				child.final = true
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	delta := func(node *trieNode, symbol string) *trieNode {
+		for node != root && node.children[symbol] == nil {
+			node = node.fail
+		}
+		if next, exists := node.children[symbol]; exists {
+			return next
+		}
+		return root
+	}
+
+	fa, nodes := trieToAutomaton(root, alphabet, delta)
+	matcher := &PatternMatcher{Automaton: fa, Outputs: make(map[string][]string)}
+	for _, node := range nodes {
+		if len(node.outputs) > 0 {
+			matcher.Outputs[node.name] = node.outputs
+		}
+	}
+
+	return matcher, nil
+}
+
+// trieToAutomaton names the reachable nodes q0, q1, ... in breadth-first
+// order. With a nil delta only the trie edges become transitions; otherwise
+// delta gives the (complete) transition function.
+func trieToAutomaton(root *trieNode, alphabet []string, delta func(*trieNode, string) *trieNode) (*FiniteAutomaton, []*trieNode) {
+	fa := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     alphabet,
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: "q0",
+		FinalStates:  []string{},
+	}
+
+	next := func(node *trieNode, symbol string) *trieNode {
+		if delta != nil {
+			return delta(node, symbol)
+		}
+		return node.children[symbol]
+	}
+
+	root.name = "q0"
+	named := map[*trieNode]bool{root: true}
+	nodes := []*trieNode{root}
+
+	for i := 0; i < len(nodes); i++ {
+		node := nodes[i]
+		fa.States = append(fa.States, node.name)
+		fa.Transitions[node.name] = make(map[string][]string)
+		if node.final {
+			fa.FinalStates = append(fa.FinalStates, node.name)
+		}
+
+		for _, symbol := range alphabet {
+			to := next(node, symbol)
+			if to == nil {
+				continue
+			}
+			if !named[to] {
+				named[to] = true
+				to.name = fmt.Sprintf("q%d", len(nodes))
+				nodes = append(nodes, to)
+			}
+			fa.Transitions[node.name][symbol] = []string{to.name}
+		}
+	}
+
+	return fa, nodes
+}
+
+// Search returns every occurrence of the patterns in text, ordered by end
+// position and, for the same end, longest first. Symbols outside the
+// alphabet reset the automaton.
+func (m *PatternMatcher) Search(text string) []PatternMatch {
+	matches := []PatternMatch{}
+	state := m.Automaton.InitialState
+
+	for i, char := range text {
+		symbol := string(char)
+		if !m.Automaton.IsInAlphabet(symbol) {
+			state = m.Automaton.InitialState
+			continue
+		}
+
+		state = m.Automaton.Transitions[state][symbol][0]
+		end := i + len(symbol)
+		for _, pattern := range m.Outputs[state] {
+			matches = append(matches, PatternMatch{Pattern: pattern, Start: end - len(pattern), End: end})
+		}
+	}
+
+	return matches
+}
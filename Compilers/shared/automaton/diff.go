@@ -0,0 +1,351 @@
+package automaton
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PositionMove is a state drawn at a different place in the second automaton.
+type PositionMove struct {
+	State string   `json:"state"`
+	From  Position `json:"from"`
+	To    Position `json:"to"`
+}
+
+// WeightChange is a transition, or with an empty Symbol and To the final
+// weight of state From, whose weight differs between the two automata.
+type WeightChange struct {
+	From   string  `json:"from"`
+	Symbol string  `json:"symbol,omitempty"`
+	To     string  `json:"to,omitempty"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+// AutomatonDiff lists what changes from a to b. States are compared by name,
+// or, for diffs built by DiffIsomorphic, through Mapping (a state -> b state);
+// every name in the diff is then a name from b. Partial marks a Mapping that
+// is only a best-effort matching, because the automata are not isomorphic.
+type AutomatonDiff struct {
+	AddedStates        []string          `json:"addedStates"`
+	RemovedStates      []string          `json:"removedStates"`
+	AddedSymbols       []string          `json:"addedSymbols"`
+	RemovedSymbols     []string          `json:"removedSymbols"`
+	AddedTransitions   []Transition      `json:"addedTransitions"`
+	RemovedTransitions []Transition      `json:"removedTransitions"`
+	InitialBefore      string            `json:"initialBefore"`
+	InitialAfter       string            `json:"initialAfter"`
+	AddedFinal         []string          `json:"addedFinal"`
+	RemovedFinal       []string          `json:"removedFinal"`
+	MovedStates        []PositionMove    `json:"movedStates"`
+	SemiringBefore     string            `json:"semiringBefore,omitempty"`
+	SemiringAfter      string            `json:"semiringAfter,omitempty"`
+	ChangedWeights     []WeightChange    `json:"changedWeights"`
+	Mapping            map[string]string `json:"mapping,omitempty"`
+	Partial            bool              `json:"partial,omitempty"`
+}
+
+// Diff compares two automata matching states by name.
+func Diff(a, b *FiniteAutomaton) *AutomatonDiff {
+	d := &AutomatonDiff{
+		AddedStates:    missingFrom(b.States, a.States),
+		RemovedStates:  missingFrom(a.States, b.States),
+		AddedSymbols:   missingFrom(b.Alphabet, a.Alphabet),
+		RemovedSymbols: missingFrom(a.Alphabet, b.Alphabet),
+		InitialBefore:  a.InitialState,
+		InitialAfter:   b.InitialState,
+		AddedFinal:     missingFrom(b.FinalStates, a.FinalStates),
+		RemovedFinal:   missingFrom(a.FinalStates, b.FinalStates),
+		MovedStates:    []PositionMove{},
+		SemiringBefore: a.weightKind(),
+		SemiringAfter:  b.weightKind(),
+		ChangedWeights: []WeightChange{},
+	}
+
+	edgesA, edgesB := a.TransitionList(), b.TransitionList()
+	d.AddedTransitions = missingTransitions(edgesB, edgesA)
+	d.RemovedTransitions = missingTransitions(edgesA, edgesB)
+
+	// Weights are compared on what both automata have, when both are
+	// weighted over the same semiring; a weight left out counts as its
+	// default value.
+	if d.SemiringBefore != "" && d.SemiringBefore == d.SemiringAfter {
+		common := make(map[Transition]bool)
+		for _, t := range edgesA {
+			common[t] = true
+		}
+		for _, t := range edgesB {
+			if !common[t] {
+				continue
+			}
+			before, after := a.TransitionWeight(t.From, t.Symbol, t.To), b.TransitionWeight(t.From, t.Symbol, t.To)
+			if before != after {
+				d.ChangedWeights = append(d.ChangedWeights, WeightChange{From: t.From, Symbol: t.Symbol, To: t.To, Before: before, After: after})
+			}
+		}
+		for _, state := range b.FinalStates {
+			if !a.IsFinalState(state) {
+				continue
+			}
+			if before, after := a.FinalWeight(state), b.FinalWeight(state); before != after {
+				d.ChangedWeights = append(d.ChangedWeights, WeightChange{From: state, Before: before, After: after})
+			}
+		}
+	}
+
+	for _, state := range b.States {
+		from, inA := a.Positions[state]
+		to, inB := b.Positions[state]
+		if inA && inB && from != to {
+			d.MovedStates = append(d.MovedStates, PositionMove{State: state, From: from, To: to})
+		}
+	}
+
+	return d
+}
+
+// DiffIsomorphic renames the states of a after an isomorphism onto b, when
+// one exists, and then compares by name; only position moves can remain.
+// Otherwise the states are matched greedily (see greedyMatching) and the
+// states of a left unmatched keep their names, primed when b uses them.
+func DiffIsomorphic(a, b *FiniteAutomaton) *AutomatonDiff {
+	mapping, found := Isomorphism(a, b)
+	if !found {
+		mapping = greedyMatching(a, b)
+	}
+
+	names := make(map[string]string)
+	used := make(map[string]bool)
+	for state, target := range mapping {
+		names[state] = target
+		used[target] = true
+	}
+	for _, state := range a.States {
+		if _, matched := names[state]; !matched {
+			names[state] = freshName(state, used)
+		}
+	}
+
+	renamed := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     append([]string{}, a.Alphabet...),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: names[a.InitialState],
+		FinalStates:  []string{},
+	}
+	for _, state := range a.States {
+		renamed.States = append(renamed.States, names[state])
+	}
+	for _, state := range a.FinalStates {
+		renamed.FinalStates = append(renamed.FinalStates, names[state])
+	}
+	for _, t := range a.TransitionList() {
+		addTransitionUnchecked(renamed, names[t.From], t.Symbol, names[t.To])
+	}
+	if a.Positions != nil {
+		renamed.Positions = make(map[string]Position)
+		for state, position := range a.Positions {
+			if name, exists := names[state]; exists {
+				renamed.Positions[name] = position
+			}
+		}
+	}
+	renamed.Semiring = a.Semiring
+	for from, bySymbol := range a.Weights {
+		for symbol, byTarget := range bySymbol {
+			for to, w := range byTarget {
+				renamed.SetTransitionWeight(names[from], symbol, names[to], w)
+			}
+		}
+	}
+	for state, w := range a.FinalWeights {
+		renamed.SetFinalWeight(names[state], w)
+	}
+
+	d := Diff(renamed, b)
+	d.Mapping = mapping
+	d.Partial = !found
+	return d
+}
+
+// greedyMatching pairs the states of a with states of b in breadth-first
+// order from the initial states: for a matched pair p, q and each symbol,
+// the unmatched targets of p are paired, in name order, with the unmatched
+// targets of q. States that cannot be reached this way stay unmatched.
+func greedyMatching(a, b *FiniteAutomaton) map[string]string {
+	mapping := make(map[string]string)
+	if !contains(a.States, a.InitialState) || !contains(b.States, b.InitialState) {
+		return mapping
+	}
+
+	used := map[string]bool{b.InitialState: true}
+	mapping[a.InitialState] = b.InitialState
+	queue := []string{a.InitialState}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		q := mapping[p]
+
+		for _, symbol := range a.canonicalSymbols() {
+			targetsA := append([]string{}, a.Transitions[p][symbol]...)
+			targetsB := append([]string{}, b.Transitions[q][symbol]...)
+			sort.Strings(targetsA)
+			sort.Strings(targetsB)
+
+			for _, next := range targetsA {
+				if _, matched := mapping[next]; matched {
+					continue
+				}
+				for _, candidate := range targetsB {
+					if !used[candidate] {
+						mapping[next] = candidate
+						used[candidate] = true
+						queue = append(queue, next)
+						break
+					}
+				}
+			}
+		}
+	}
+	return mapping
+}
+
+func semiringLabel(name string) string {
+	if name == "" {
+		return "fără ponderi"
+	}
+	return name
+}
+
+func missingFrom(items, other []string) []string {
+	missing := []string{}
+	for _, item := range items {
+		if !contains(other, item) && !contains(missing, item) {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
+
+func missingTransitions(items, other []Transition) []Transition {
+	present := make(map[Transition]bool)
+	for _, t := range other {
+		present[t] = true
+	}
+
+	missing := []Transition{}
+	for _, t := range items {
+		if !present[t] {
+			missing = append(missing, t)
+		}
+	}
+	return missing
+}
+
+func (d *AutomatonDiff) IsEmpty() bool {
+	return len(d.AddedStates) == 0 && len(d.RemovedStates) == 0 &&
+		len(d.AddedSymbols) == 0 && len(d.RemovedSymbols) == 0 &&
+		len(d.AddedTransitions) == 0 && len(d.RemovedTransitions) == 0 &&
+		d.InitialBefore == d.InitialAfter &&
+		len(d.AddedFinal) == 0 && len(d.RemovedFinal) == 0 &&
+		len(d.MovedStates) == 0 &&
+		d.SemiringBefore == d.SemiringAfter && len(d.ChangedWeights) == 0
+}
+
+// String prints the diff with "+" for additions, "-" for removals and "~"
+// for changes, one item per line.
+func (d *AutomatonDiff) String() string {
+	var sb strings.Builder
+
+	renamed := []string{}
+	for state, target := range d.Mapping {
+		if state != target {
+			renamed = append(renamed, state)
+		}
+	}
+	sort.Strings(renamed)
+
+	if d.Partial {
+		sb.WriteString("Automatele nu sunt izomorfe: stările sunt potrivite parțial, pornind din\n")
+		sb.WriteString("starea inițială, iar cele rămase nepotrivite sunt comparate după nume.\n\n")
+	}
+
+	if len(renamed) > 0 {
+		title := "Stări potrivite prin izomorfism:\n"
+		if d.Partial {
+			title = "Stări potrivite:\n"
+		}
+		sb.WriteString(title)
+		for _, state := range renamed {
+			sb.WriteString(fmt.Sprintf("  %s → %s\n", state, d.Mapping[state]))
+		}
+		sb.WriteString("\n")
+	}
+
+	if d.IsEmpty() {
+		if len(renamed) > 0 {
+			sb.WriteString("Automatele sunt identice după redenumirea stărilor.\n")
+		} else {
+			sb.WriteString("Automatele sunt identice.\n")
+		}
+		return sb.String()
+	}
+
+	writeList := func(title string, added, removed []string) {
+		if len(added) == 0 && len(removed) == 0 {
+			return
+		}
+		sb.WriteString(title + ":\n")
+		for _, item := range added {
+			sb.WriteString(fmt.Sprintf("  + %s\n", item))
+		}
+		for _, item := range removed {
+			sb.WriteString(fmt.Sprintf("  - %s\n", item))
+		}
+	}
+
+	writeList("Stări", d.AddedStates, d.RemovedStates)
+	writeList("Alfabet", d.AddedSymbols, d.RemovedSymbols)
+
+	if len(d.AddedTransitions) > 0 || len(d.RemovedTransitions) > 0 {
+		sb.WriteString("Tranziții:\n")
+		for _, t := range d.AddedTransitions {
+			sb.WriteString(fmt.Sprintf("  + %s --%s--> %s\n", t.From, t.Symbol, t.To))
+		}
+		for _, t := range d.RemovedTransitions {
+			sb.WriteString(fmt.Sprintf("  - %s --%s--> %s\n", t.From, t.Symbol, t.To))
+		}
+	}
+
+	if d.InitialBefore != d.InitialAfter {
+		sb.WriteString(fmt.Sprintf("Stare inițială:\n  ~ %s → %s\n", d.InitialBefore, d.InitialAfter))
+	}
+
+	writeList("Stări finale", d.AddedFinal, d.RemovedFinal)
+
+	if d.SemiringBefore != d.SemiringAfter {
+		sb.WriteString(fmt.Sprintf("Semiinel:\n  ~ %s → %s\n", semiringLabel(d.SemiringBefore), semiringLabel(d.SemiringAfter)))
+	}
+
+	if len(d.ChangedWeights) > 0 {
+		sb.WriteString("Ponderi:\n")
+		for _, change := range d.ChangedWeights {
+			item := fmt.Sprintf("%s --%s--> %s", change.From, change.Symbol, change.To)
+			if change.Symbol == "" && change.To == "" {
+				item = "final " + change.From
+			}
+			sb.WriteString(fmt.Sprintf("  ~ %s: %s → %s\n", item, FormatWeight(change.Before), FormatWeight(change.After)))
+		}
+	}
+
+	if len(d.MovedStates) > 0 {
+		sb.WriteString("Poziții:\n")
+		for _, move := range d.MovedStates {
+			sb.WriteString(fmt.Sprintf("  ~ %s: (%.0f, %.0f) → (%.0f, %.0f)\n",
+				move.State, move.From.X, move.From.Y, move.To.X, move.To.Y))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,57 @@
+package automaton
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToDOT writes the automaton in the Graphviz DOT language: final states are
+// double circles, the initial state has an arrow from an invisible node, and
+// parallel transitions share one edge labelled with all their symbols.
+func (fa *FiniteAutomaton) ToDOT() string {
+	var sb strings.Builder
+
+	name := fa.Name
+	if name == "" {
+		name = "automat"
+	}
+	sb.WriteString(fmt.Sprintf("digraph %s {\n", dotQuote(name)))
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=circle];\n")
+	sb.WriteString("  __start [shape=point, style=invis];\n")
+
+	for _, state := range fa.States {
+		if fa.IsFinalState(state) {
+			sb.WriteString(fmt.Sprintf("  %s [shape=doublecircle];\n", dotQuote(state)))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s;\n", dotQuote(state)))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("  __start -> %s;\n", dotQuote(fa.InitialState)))
+
+	type edge struct{ from, to string }
+	labels := make(map[edge][]string)
+	edges := []edge{}
+	for _, t := range fa.TransitionList() {
+		e := edge{t.From, t.To}
+		if _, exists := labels[e]; !exists {
+			edges = append(edges, e)
+		}
+		labels[e] = append(labels[e], fa.TransitionLabel(t))
+	}
+	for _, e := range edges {
+		sb.WriteString(fmt.Sprintf("  %s -> %s [label=%s];\n",
+			dotQuote(e.from), dotQuote(e.to), dotQuote(strings.Join(labels[e], ", "))))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotQuote writes s as a DOT string, which only needs quotes and
+// backslashes escaped.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
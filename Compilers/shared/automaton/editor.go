@@ -0,0 +1,453 @@
+package automaton
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Editing operations recorded by an Editor.
+const (
+	OpAddState         = "addState"
+	OpRemoveState      = "removeState"
+	OpRenameState      = "renameState"
+	OpSetInitialState  = "setInitialState"
+	OpToggleFinalState = "toggleFinalState"
+	OpSetStatePosition = "setStatePosition"
+	OpAddTransition    = "addTransition"
+	OpRemoveTransition = "removeTransition"
+	OpSetWeight        = "setWeight"
+	OpSetFinalWeight   = "setFinalWeight"
+)
+
+// EditCommand is one editing operation. State is the state it acts on (the
+// source state for transitions); Undo is filled in when the command runs and
+// holds what is needed to invert it exactly.
+type EditCommand struct {
+	Op      string    `json:"op"`
+	State   string    `json:"state"`
+	NewName string    `json:"newName,omitempty"`
+	Symbol  string    `json:"symbol,omitempty"`
+	To      string    `json:"to,omitempty"`
+	X       float64   `json:"x,omitempty"`
+	Y       float64   `json:"y,omitempty"`
+	Weight  float64   `json:"weight,omitempty"`
+	Undo    *EditUndo `json:"undo,omitempty"`
+}
+
+// EditUndo is the part of the automaton a command overwrote or dropped.
+type EditUndo struct {
+	Index      int                 `json:"index"`      // in States, or in the target list of a transition
+	FinalIndex int                 `json:"finalIndex"` // in FinalStates, -1 when the state was not final
+	Initial    string              `json:"initial,omitempty"`
+	Position   *Position           `json:"position,omitempty"`
+	Outgoing   map[string][]string `json:"outgoing,omitempty"`
+	Incoming   []EditTargetList    `json:"incoming,omitempty"`
+
+	// Weights and FinalWeights are copies taken before a command that can
+	// change or drop weights.
+	Weights      map[string]map[string]map[string]float64 `json:"weights,omitempty"`
+	FinalWeights map[string]float64                       `json:"finalWeights,omitempty"`
+}
+
+// EditTargetList is a full target list, saved before a state was removed from it.
+type EditTargetList struct {
+	From    string   `json:"from"`
+	Symbol  string   `json:"symbol"`
+	Targets []string `json:"targets"`
+}
+
+// EditEntry is one undoable step: a single command or a whole transaction.
+type EditEntry struct {
+	Label    string        `json:"label"`
+	Commands []EditCommand `json:"commands"`
+}
+
+// Editor wraps an automaton and records every edit, so edits can be undone
+// and redone. Edits made between Begin and Commit form one entry. The editor,
+// including its history and an open transaction, serialises to JSON.
+type Editor struct {
+	Automaton *FiniteAutomaton `json:"automaton"`
+	Done      []EditEntry      `json:"done"`
+	Undone    []EditEntry      `json:"undone"`
+	Pending   *EditEntry       `json:"pending,omitempty"`
+}
+
+func NewEditor(fa *FiniteAutomaton) *Editor {
+	return &Editor{
+		Automaton: fa,
+		Done:      []EditEntry{},
+		Undone:    []EditEntry{},
+	}
+}
+
+func (e *Editor) AddState(name string) error {
+	return e.Apply(EditCommand{Op: OpAddState, State: name})
+}
+
+// RemoveState also removes the incident transitions; undoing it restores them.
+func (e *Editor) RemoveState(name string) error {
+	return e.Apply(EditCommand{Op: OpRemoveState, State: name})
+}
+
+func (e *Editor) RenameState(oldName, newName string) error {
+	return e.Apply(EditCommand{Op: OpRenameState, State: oldName, NewName: newName})
+}
+
+func (e *Editor) SetInitialState(state string) error {
+	return e.Apply(EditCommand{Op: OpSetInitialState, State: state})
+}
+
+func (e *Editor) ToggleFinalState(state string) error {
+	return e.Apply(EditCommand{Op: OpToggleFinalState, State: state})
+}
+
+func (e *Editor) SetStatePosition(state string, x, y float64) error {
+	return e.Apply(EditCommand{Op: OpSetStatePosition, State: state, X: x, Y: y})
+}
+
+func (e *Editor) AddTransition(from, symbol, to string) error {
+	return e.Apply(EditCommand{Op: OpAddTransition, State: from, Symbol: symbol, To: to})
+}
+
+func (e *Editor) RemoveTransition(from, symbol, to string) error {
+	return e.Apply(EditCommand{Op: OpRemoveTransition, State: from, Symbol: symbol, To: to})
+}
+
+func (e *Editor) SetTransitionWeight(from, symbol, to string, weight float64) error {
+	return e.Apply(EditCommand{Op: OpSetWeight, State: from, Symbol: symbol, To: to, Weight: weight})
+}
+
+func (e *Editor) SetFinalWeight(state string, weight float64) error {
+	return e.Apply(EditCommand{Op: OpSetFinalWeight, State: state, Weight: weight})
+}
+
+// Apply runs a command and records it, in the open transaction if there is
+// one. A new edit clears the redo history.
+func (e *Editor) Apply(cmd EditCommand) error {
+	if err := e.execute(&cmd); err != nil {
+		return err
+	}
+
+	if e.Pending != nil {
+		e.Pending.Commands = append(e.Pending.Commands, cmd)
+		return nil
+	}
+
+	e.Done = append(e.Done, EditEntry{Label: cmd.Op, Commands: []EditCommand{cmd}})
+	e.Undone = []EditEntry{}
+	return nil
+}
+
+// Begin opens a transaction; its commands are undone and redone together.
+func (e *Editor) Begin(label string) error {
+	if e.Pending != nil {
+		return fmt.Errorf("există deja o tranzacție deschisă (%s)", e.Pending.Label)
+	}
+
+	e.Pending = &EditEntry{Label: label, Commands: []EditCommand{}}
+	return nil
+}
+
+func (e *Editor) Commit() error {
+	if e.Pending == nil {
+		return fmt.Errorf("nu există nicio tranzacție deschisă")
+	}
+
+	if len(e.Pending.Commands) > 0 {
+		e.Done = append(e.Done, *e.Pending)
+		e.Undone = []EditEntry{}
+	}
+	e.Pending = nil
+	return nil
+}
+
+// Rollback reverts the commands of the open transaction and closes it.
+func (e *Editor) Rollback() error {
+	if e.Pending == nil {
+		return fmt.Errorf("nu există nicio tranzacție deschisă")
+	}
+
+	e.revert(e.Pending.Commands)
+	e.Pending = nil
+	return nil
+}
+
+func (e *Editor) CanUndo() bool {
+	return e.Pending == nil && len(e.Done) > 0
+}
+
+func (e *Editor) CanRedo() bool {
+	return e.Pending == nil && len(e.Undone) > 0
+}
+
+func (e *Editor) Undo() error {
+	if e.Pending != nil {
+		return fmt.Errorf("închideți mai întâi tranzacția deschisă (%s)", e.Pending.Label)
+	}
+	if len(e.Done) == 0 {
+		return fmt.Errorf("nu există nicio modificare de anulat")
+	}
+
+	entry := e.Done[len(e.Done)-1]
+	e.Done = e.Done[:len(e.Done)-1]
+	e.revert(entry.Commands)
+	e.Undone = append(e.Undone, entry)
+	return nil
+}
+
+func (e *Editor) Redo() error {
+	if e.Pending != nil {
+		return fmt.Errorf("închideți mai întâi tranzacția deschisă (%s)", e.Pending.Label)
+	}
+	if len(e.Undone) == 0 {
+		return fmt.Errorf("nu există nicio modificare de refăcut")
+	}
+
+	entry := e.Undone[len(e.Undone)-1]
+	for i := range entry.Commands {
+		if err := e.execute(&entry.Commands[i]); err != nil {
+			e.revert(entry.Commands[:i])
+			return fmt.Errorf("modificarea nu mai poate fi refăcută: %v", err)
+		}
+	}
+
+	e.Undone = e.Undone[:len(e.Undone)-1]
+	e.Done = append(e.Done, entry)
+	return nil
+}
+
+func (e *Editor) revert(commands []EditCommand) {
+	for i := len(commands) - 1; i >= 0; i-- {
+		e.invert(commands[i])
+	}
+}
+
+// execute runs the command through the FiniteAutomaton editing API, after
+// saving in cmd.Undo what the operation overwrites or drops.
+func (e *Editor) execute(cmd *EditCommand) error {
+	fa := e.Automaton
+	undo := &EditUndo{
+		Index:      indexOf(fa.States, cmd.State),
+		FinalIndex: indexOf(fa.FinalStates, cmd.State),
+		Initial:    fa.InitialState,
+	}
+	if changesWeights(cmd.Op) {
+		undo.Weights, undo.FinalWeights = fa.copyWeights()
+	}
+
+	var err error
+	switch cmd.Op {
+	case OpAddState:
+		err = fa.AddState(cmd.State)
+	case OpRemoveState:
+		undo.Outgoing = fa.Transitions[cmd.State]
+		for _, from := range orderedKeys(fa.States, fa.Transitions) {
+			if from == cmd.State {
+				continue
+			}
+			for _, symbol := range orderedKeys(fa.Alphabet, fa.Transitions[from]) {
+				targets := fa.Transitions[from][symbol]
+				if contains(targets, cmd.State) {
+					undo.Incoming = append(undo.Incoming, EditTargetList{
+						From: from, Symbol: symbol, Targets: append([]string{}, targets...),
+					})
+				}
+			}
+		}
+		if position, exists := fa.Positions[cmd.State]; exists {
+			undo.Position = &position
+		}
+		err = fa.RemoveState(cmd.State)
+	case OpRenameState:
+		err = fa.RenameState(cmd.State, cmd.NewName)
+	case OpSetInitialState:
+		err = fa.SetInitialState(cmd.State)
+	case OpToggleFinalState:
+		err = fa.ToggleFinalState(cmd.State)
+	case OpSetStatePosition:
+		if position, exists := fa.Positions[cmd.State]; exists {
+			undo.Position = &position
+		}
+		err = fa.SetStatePosition(cmd.State, cmd.X, cmd.Y)
+	case OpAddTransition:
+		err = fa.AddTransition(cmd.State, cmd.Symbol, cmd.To)
+	case OpRemoveTransition:
+		undo.Index = indexOf(fa.Transitions[cmd.State][cmd.Symbol], cmd.To)
+		err = fa.RemoveTransition(cmd.State, cmd.Symbol, cmd.To)
+	case OpSetWeight:
+		err = fa.SetTransitionWeight(cmd.State, cmd.Symbol, cmd.To, cmd.Weight)
+	case OpSetFinalWeight:
+		err = fa.SetFinalWeight(cmd.State, cmd.Weight)
+	default:
+		err = fmt.Errorf("operația '%s' nu este cunoscută", cmd.Op)
+	}
+
+	if err != nil {
+		return err
+	}
+	cmd.Undo = undo
+	return nil
+}
+
+// invert restores the automaton to its state before cmd ran.
+func (e *Editor) invert(cmd EditCommand) {
+	fa := e.Automaton
+	undo := cmd.Undo
+
+	switch cmd.Op {
+	case OpAddState:
+		// The new state has no transitions of its own yet; removing it
+		// directly also works when it is the only state.
+		fa.States = fa.States[:len(fa.States)-1]
+		delete(fa.Transitions, cmd.State)
+		fa.InitialState = undo.Initial
+	case OpRemoveState:
+		fa.States = insertAt(fa.States, undo.Index, cmd.State)
+		if undo.FinalIndex >= 0 {
+			fa.FinalStates = insertAt(fa.FinalStates, undo.FinalIndex, cmd.State)
+		}
+		fa.InitialState = undo.Initial
+		if undo.Outgoing != nil {
+			fa.Transitions[cmd.State] = undo.Outgoing
+		}
+		for _, list := range undo.Incoming {
+			if fa.Transitions[list.From] == nil {
+				fa.Transitions[list.From] = make(map[string][]string)
+			}
+			fa.Transitions[list.From][list.Symbol] = append([]string{}, list.Targets...)
+		}
+		if undo.Position != nil {
+			fa.SetStatePosition(cmd.State, undo.Position.X, undo.Position.Y)
+		}
+	case OpRenameState:
+		fa.RenameState(cmd.NewName, cmd.State)
+	case OpSetInitialState:
+		fa.InitialState = undo.Initial
+	case OpToggleFinalState:
+		if undo.FinalIndex >= 0 {
+			fa.FinalStates = insertAt(fa.FinalStates, undo.FinalIndex, cmd.State)
+		} else {
+			fa.ToggleFinalState(cmd.State)
+		}
+	case OpSetStatePosition:
+		if undo.Position != nil {
+			fa.SetStatePosition(cmd.State, undo.Position.X, undo.Position.Y)
+		} else {
+			delete(fa.Positions, cmd.State)
+		}
+	case OpAddTransition:
+		fa.RemoveTransition(cmd.State, cmd.Symbol, cmd.To)
+	case OpRemoveTransition:
+		if fa.Transitions[cmd.State] == nil {
+			fa.Transitions[cmd.State] = make(map[string][]string)
+		}
+		fa.Transitions[cmd.State][cmd.Symbol] = insertAt(fa.Transitions[cmd.State][cmd.Symbol], undo.Index, cmd.To)
+	}
+
+	if changesWeights(cmd.Op) {
+		// Restore a copy, so later edits leave the saved history unchanged.
+		fa.Weights, fa.FinalWeights = undo.Weights, undo.FinalWeights
+		fa.Weights, fa.FinalWeights = fa.copyWeights()
+	}
+}
+
+// changesWeights reports whether op can set or drop weights.
+func changesWeights(op string) bool {
+	switch op {
+	case OpRemoveState, OpToggleFinalState, OpRemoveTransition, OpSetWeight, OpSetFinalWeight:
+		return true
+	}
+	return false
+}
+
+// copyWeights returns deep copies of the weight maps, nil when they are empty.
+func (fa *FiniteAutomaton) copyWeights() (map[string]map[string]map[string]float64, map[string]float64) {
+	var weights map[string]map[string]map[string]float64
+	for from, bySymbol := range fa.Weights {
+		for symbol, byTarget := range bySymbol {
+			for to, w := range byTarget {
+				if weights == nil {
+					weights = make(map[string]map[string]map[string]float64)
+				}
+				if weights[from] == nil {
+					weights[from] = make(map[string]map[string]float64)
+				}
+				if weights[from][symbol] == nil {
+					weights[from][symbol] = make(map[string]float64)
+				}
+				weights[from][symbol][to] = w
+			}
+		}
+	}
+
+	var finalWeights map[string]float64
+	for state, w := range fa.FinalWeights {
+		if finalWeights == nil {
+			finalWeights = make(map[string]float64)
+		}
+		finalWeights[state] = w
+	}
+	return weights, finalWeights
+}
+
+func indexOf(slice []string, item string) int {
+	for i, s := range slice {
+		if s == item {
+			return i
+		}
+	}
+	return -1
+}
+
+func insertAt(slice []string, index int, item string) []string {
+	if index < 0 || index > len(slice) {
+		index = len(slice)
+	}
+	result := make([]string, 0, len(slice)+1)
+	result = append(result, slice[:index]...)
+	result = append(result, item)
+	return append(result, slice[index:]...)
+}
+
+func ParseEditorFromJSON(jsonStr string) (*Editor, error) {
+	var e Editor
+	if err := json.Unmarshal([]byte(jsonStr), &e); err != nil {
+		return nil, fmt.Errorf("eroare la parsarea JSON: %v", err)
+	}
+
+	if e.Automaton == nil {
+		return nil, fmt.Errorf("editorul nu conține niciun automat")
+	}
+	if err := e.Automaton.Validate(); err != nil {
+		return nil, fmt.Errorf("automat invalid: %v", err)
+	}
+	if e.Automaton.Transitions == nil {
+		e.Automaton.Transitions = make(map[string]map[string][]string)
+	}
+	if e.Done == nil {
+		e.Done = []EditEntry{}
+	}
+	if e.Undone == nil {
+		e.Undone = []EditEntry{}
+	}
+
+	return &e, nil
+}
+
+func ParseEditorFromFile(filename string) (*Editor, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+
+	return ParseEditorFromJSON(string(data))
+}
+
+func (e *Editor) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("eroare la serializarea JSON: %v", err)
+	}
+	return string(data), nil
+}
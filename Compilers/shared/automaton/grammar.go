@@ -0,0 +1,565 @@
+package automaton
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	RightLinear = "right"
+	LeftLinear  = "left"
+)
+
+// RegularGrammar is a grammar whose productions are either right-linear
+// (A -> w B | w) or left-linear (A -> B w | w), with w a string of terminals.
+// Each alternative is a sequence of symbols; an empty one stands for ε.
+type RegularGrammar struct {
+	Nonterminals []string              `json:"nonterminals"`
+	Terminals    []string              `json:"terminals"`
+	Start        string                `json:"start"`
+	Productions  map[string][][]string `json:"productions"`
+}
+
+func (g *RegularGrammar) IsNonterminal(symbol string) bool {
+	return contains(g.Nonterminals, symbol)
+}
+
+func (g *RegularGrammar) IsTerminal(symbol string) bool {
+	return contains(g.Terminals, symbol)
+}
+
+func (g *RegularGrammar) Validate() error {
+	if len(g.Nonterminals) == 0 {
+		return fmt.Errorf("gramatica trebuie să aibă cel puțin un neterminal")
+	}
+
+	for _, terminal := range g.Terminals {
+		if utf8.RuneCountInString(terminal) != 1 {
+			return fmt.Errorf("terminalul '%s' trebuie să fie un singur caracter", terminal)
+		}
+		if g.IsNonterminal(terminal) {
+			return fmt.Errorf("simbolul '%s' este atât terminal, cât și neterminal", terminal)
+		}
+	}
+
+	if !g.IsNonterminal(g.Start) {
+		return fmt.Errorf("simbolul de start '%s' nu există în mulțimea neterminalelor", g.Start)
+	}
+
+	for left, alternatives := range g.Productions {
+		if !g.IsNonterminal(left) {
+			return fmt.Errorf("neterminalul '%s' din producții nu există", left)
+		}
+
+		for _, alternative := range alternatives {
+			for _, symbol := range alternative {
+				if !g.IsNonterminal(symbol) && !g.IsTerminal(symbol) {
+					return fmt.Errorf("simbolul '%s' din producția lui %s nu este nici terminal, nici neterminal", symbol, left)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// isRightLinearAlternative accepts w and w B, with w a (possibly empty)
+// string of terminals.
+func (g *RegularGrammar) isRightLinearAlternative(alternative []string) bool {
+	for i, symbol := range alternative {
+		if g.IsNonterminal(symbol) && i != len(alternative)-1 {
+			return false
+		}
+	}
+	return true
+}
+
+// isLeftLinearAlternative accepts w and B w.
+func (g *RegularGrammar) isLeftLinearAlternative(alternative []string) bool {
+	for i, symbol := range alternative {
+		if g.IsNonterminal(symbol) && i != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *RegularGrammar) IsRightLinear() bool {
+	for _, alternatives := range g.Productions {
+		for _, alternative := range alternatives {
+			if !g.isRightLinearAlternative(alternative) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (g *RegularGrammar) IsLeftLinear() bool {
+	for _, alternatives := range g.Productions {
+		for _, alternative := range alternatives {
+			if !g.isLeftLinearAlternative(alternative) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Linearity returns RightLinear or LeftLinear, or an error when the grammar
+// is neither (or mixes both forms). A grammar without nonterminals on the
+// right-hand side is both; it is reported as right-linear.
+func (g *RegularGrammar) Linearity() (string, error) {
+	if g.IsRightLinear() {
+		return RightLinear, nil
+	}
+	if g.IsLeftLinear() {
+		return LeftLinear, nil
+	}
+	return "", fmt.Errorf("gramatica nu este nici liniară la dreapta, nici liniară la stânga")
+}
+
+func (g *RegularGrammar) TypeString() string {
+	switch linearity, _ := g.Linearity(); linearity {
+	case RightLinear:
+		return "Gramatică regulară (liniară la dreapta)"
+	case LeftLinear:
+		return "Gramatică regulară (liniară la stânga)"
+	}
+	return "Gramatică neregulară"
+}
+
+// String prints the grammar in the same syntax ParseGrammar reads.
+func (g *RegularGrammar) String() string {
+	var sb strings.Builder
+
+	for _, left := range g.Nonterminals {
+		alternatives := g.Productions[left]
+		if len(alternatives) == 0 {
+			continue
+		}
+
+		parts := make([]string, len(alternatives))
+		for i, alternative := range alternatives {
+			parts[i] = g.formatAlternative(alternative)
+		}
+		sb.WriteString(fmt.Sprintf("%s = %s ;\n", left, strings.Join(parts, " | ")))
+	}
+
+	return sb.String()
+}
+
+func (g *RegularGrammar) formatAlternative(alternative []string) string {
+	if len(alternative) == 0 {
+		return Epsilon
+	}
+
+	symbols := make([]string, len(alternative))
+	for i, symbol := range alternative {
+		if g.IsTerminal(symbol) {
+			symbols[i] = fmt.Sprintf("%q", symbol)
+		} else {
+			symbols[i] = symbol
+		}
+	}
+	return strings.Join(symbols, " ")
+}
+
+// withoutUnitProductions replaces every A -> B by the non-unit alternatives
+// of B, following chains of unit productions.
+func (g *RegularGrammar) withoutUnitProductions() map[string][][]string {
+	result := make(map[string][][]string)
+
+	for _, left := range g.Nonterminals {
+		reachable := map[string]bool{left: true}
+		queue := []string{left}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, alternative := range g.Productions[current] {
+				if len(alternative) == 1 && g.IsNonterminal(alternative[0]) && !reachable[alternative[0]] {
+					reachable[alternative[0]] = true
+					queue = append(queue, alternative[0])
+				}
+			}
+		}
+
+		seen := make(map[string]bool)
+		for _, source := range g.Nonterminals {
+			if !reachable[source] {
+				continue
+			}
+			for _, alternative := range g.Productions[source] {
+				if len(alternative) == 1 && g.IsNonterminal(alternative[0]) {
+					continue
+				}
+				key := strings.Join(alternative, "\x00")
+				if !seen[key] {
+					seen[key] = true
+					result[left] = append(result[left], alternative)
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// GrammarToFA builds a nondeterministic FiniteAutomaton for a right- or
+// left-linear grammar. Alternatives with several terminals are spelled out
+// through fresh intermediate states.
+func GrammarToFA(g *RegularGrammar) (*FiniteAutomaton, error) {
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+
+	linearity, err := g.Linearity()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(g.Terminals) == 0 {
+		return nil, fmt.Errorf("gramatica trebuie să aibă cel puțin un terminal")
+	}
+
+	fa := &FiniteAutomaton{
+		States:      append([]string{}, g.Nonterminals...),
+		Alphabet:    append([]string{}, g.Terminals...),
+		Transitions: make(map[string]map[string][]string),
+		FinalStates: []string{},
+	}
+
+	used := make(map[string]bool)
+	for _, state := range fa.States {
+		used[state] = true
+	}
+
+	chain := func(from string, word []string, to string) {
+		current := from
+		for i, symbol := range word {
+			next := to
+			if i < len(word)-1 {
+				next = freshIndexedName(from, used)
+				fa.States = append(fa.States, next)
+			}
+			addTransitionUnchecked(fa, current, symbol, next)
+			current = next
+		}
+	}
+
+	productions := g.withoutUnitProductions()
+
+	if linearity == RightLinear {
+		fa.InitialState = g.Start
+		final := ""
+
+		for _, left := range g.Nonterminals {
+			for _, alternative := range productions[left] {
+				switch {
+				case len(alternative) == 0:
+					if !contains(fa.FinalStates, left) {
+						fa.FinalStates = append(fa.FinalStates, left)
+					}
+				case g.IsNonterminal(alternative[len(alternative)-1]):
+					chain(left, alternative[:len(alternative)-1], alternative[len(alternative)-1])
+				default:
+					if final == "" {
+						final = freshName("F", used)
+						fa.States = append(fa.States, final)
+						fa.FinalStates = append(fa.FinalStates, final)
+					}
+					chain(left, alternative, final)
+				}
+			}
+		}
+
+		return fa, nil
+	}
+
+	// Left-linear: a state B means "the prefix read so far derives from B",
+	// so A -> B w becomes B --w--> A and the start symbol is the final state.
+	initial := freshName("S0", used)
+	fa.States = append(fa.States, initial)
+	fa.InitialState = initial
+	fa.FinalStates = append(fa.FinalStates, g.Start)
+
+	nullable := make(map[string]bool)
+	for _, left := range g.Nonterminals {
+		for _, alternative := range productions[left] {
+			if len(alternative) == 0 {
+				nullable[left] = true
+			}
+		}
+	}
+	if nullable[g.Start] {
+		fa.FinalStates = append(fa.FinalStates, initial)
+	}
+
+	for _, left := range g.Nonterminals {
+		for _, alternative := range productions[left] {
+			switch {
+			case len(alternative) == 0:
+			case g.IsNonterminal(alternative[0]):
+				chain(alternative[0], alternative[1:], left)
+				if nullable[alternative[0]] {
+					chain(initial, alternative[1:], left)
+				}
+			default:
+				chain(initial, alternative, left)
+			}
+		}
+	}
+
+	return fa, nil
+}
+
+// FAToGrammar builds the right-linear grammar with one nonterminal per state:
+// p -> a q for every transition p --a--> q, and q -> ε for every final q.
+// ε-transitions are removed first.
+func FAToGrammar(fa *FiniteAutomaton) *RegularGrammar {
+	if fa.HasEpsilonTransitions() {
+		fa = fa.RemoveEpsilon()
+	}
+
+	g := &RegularGrammar{
+		Nonterminals: append([]string{}, fa.States...),
+		Terminals:    append([]string{}, fa.Alphabet...),
+		Start:        fa.InitialState,
+		Productions:  make(map[string][][]string),
+	}
+
+	for _, state := range fa.States {
+		for _, symbol := range fa.Alphabet {
+			for _, next := range fa.Transitions[state][symbol] {
+				g.Productions[state] = append(g.Productions[state], []string{symbol, next})
+			}
+		}
+		if fa.IsFinalState(state) {
+			g.Productions[state] = append(g.Productions[state], []string{})
+		}
+	}
+
+	return g
+}
+
+func addTransitionUnchecked(fa *FiniteAutomaton, from, symbol, to string) {
+	if fa.Transitions[from] == nil {
+		fa.Transitions[from] = make(map[string][]string)
+	}
+	if !contains(fa.Transitions[from][symbol], to) {
+		fa.Transitions[from][symbol] = append(fa.Transitions[from][symbol], to)
+	}
+}
+
+// freshName returns base, or base followed by apostrophes, that is not yet
+// used, and marks it as used.
+func freshName(base string, used map[string]bool) string {
+	name := base
+	for used[name] {
+		name += "'"
+	}
+	used[name] = true
+	return name
+}
+
+// freshIndexedName returns the first unused base_1, base_2, ... and marks it
+// as used.
+func freshIndexedName(base string, used map[string]bool) string {
+	for i := 1; ; i++ {
+		name := fmt.Sprintf("%s_%d", base, i)
+		if !used[name] {
+			used[name] = true
+			return name
+		}
+	}
+}
+
+// ParseGrammar reads productions written as
+//
+//	S = "a" A | "b" | ε ;
+//	A -> 'a' A | "b" S ;
+//
+// Quoted symbols are terminals, a quoted string of several characters being
+// the sequence of its characters ("ab" is "a" "b"); bare identifiers are
+// nonterminals, ε (or an empty alternative) is the empty word and (* ... *)
+// is a comment. The first rule defines the start symbol.
+func ParseGrammar(text string) (*RegularGrammar, error) {
+	tokens, err := tokenizeGrammar(text)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &RegularGrammar{
+		Nonterminals: []string{},
+		Terminals:    []string{},
+		Productions:  make(map[string][][]string),
+	}
+	identifiers := []string{}
+
+	for i := 0; i < len(tokens); {
+		left := tokens[i]
+		if left.kind != grammarIdentifier {
+			return nil, fmt.Errorf("linia %d: se așteaptă un neterminal, s-a găsit '%s'", left.line, left.text)
+		}
+		if i+1 >= len(tokens) || tokens[i+1].kind != grammarDefine {
+			return nil, fmt.Errorf("linia %d: se așteaptă '=' după '%s'", left.line, left.text)
+		}
+		i += 2
+
+		if g.Start == "" {
+			g.Start = left.text
+		}
+		if !contains(g.Nonterminals, left.text) {
+			g.Nonterminals = append(g.Nonterminals, left.text)
+		}
+
+		alternative := []string{}
+		for {
+			if i >= len(tokens) {
+				return nil, fmt.Errorf("linia %d: regula pentru '%s' nu se termină cu ';'", left.line, left.text)
+			}
+
+			token := tokens[i]
+			i++
+
+			switch token.kind {
+			case grammarTerminal:
+				if !contains(g.Terminals, token.text) {
+					g.Terminals = append(g.Terminals, token.text)
+				}
+				alternative = append(alternative, token.text)
+			case grammarIdentifier:
+				if !contains(identifiers, token.text) {
+					identifiers = append(identifiers, token.text)
+				}
+				alternative = append(alternative, token.text)
+			case grammarEpsilon:
+			case grammarBar, grammarEnd:
+				g.Productions[left.text] = append(g.Productions[left.text], alternative)
+				alternative = []string{}
+			default:
+				return nil, fmt.Errorf("linia %d: simbol neașteptat '%s'", token.line, token.text)
+			}
+
+			if token.kind == grammarEnd {
+				break
+			}
+		}
+	}
+
+	for _, identifier := range identifiers {
+		if !contains(g.Nonterminals, identifier) {
+			g.Nonterminals = append(g.Nonterminals, identifier)
+		}
+	}
+
+	if err := g.Validate(); err != nil {
+		return nil, fmt.Errorf("gramatică invalidă: %v", err)
+	}
+
+	return g, nil
+}
+
+func ParseGrammarFromFile(filename string) (*RegularGrammar, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+
+	return ParseGrammar(string(data))
+}
+
+type grammarTokenKind int
+
+const (
+	grammarIdentifier grammarTokenKind = iota
+	grammarTerminal
+	grammarEpsilon
+	grammarDefine
+	grammarBar
+	grammarEnd
+)
+
+type grammarToken struct {
+	kind grammarTokenKind
+	text string
+	line int
+}
+
+func tokenizeGrammar(text string) ([]grammarToken, error) {
+	tokens := []grammarToken{}
+	runes := []rune(text)
+	line := 1
+
+	for i := 0; i < len(runes); {
+		ch := runes[i]
+
+		switch {
+		case ch == '\n':
+			line++
+			i++
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '(' && i+1 < len(runes) && runes[i+1] == '*':
+			end := i + 2
+			for end+1 < len(runes) && !(runes[end] == '*' && runes[end+1] == ')') {
+				if runes[end] == '\n' {
+					line++
+				}
+				end++
+			}
+			if end+1 >= len(runes) {
+				return nil, fmt.Errorf("linia %d: comentariu neterminat", line)
+			}
+			i = end + 2
+		case ch == '"' || ch == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != ch && runes[end] != '\n' {
+				end++
+			}
+			if end >= len(runes) || runes[end] != ch {
+				return nil, fmt.Errorf("linia %d: șir neterminat", line)
+			}
+			literal := string(runes[i+1 : end])
+			if literal == "" {
+				tokens = append(tokens, grammarToken{grammarEpsilon, Epsilon, line})
+			}
+			for _, r := range literal {
+				tokens = append(tokens, grammarToken{grammarTerminal, string(r), line})
+			}
+			i = end + 1
+		case ch == 'ε':
+			tokens = append(tokens, grammarToken{grammarEpsilon, Epsilon, line})
+			i++
+		case ch == '=':
+			tokens = append(tokens, grammarToken{grammarDefine, "=", line})
+			i++
+		case ch == ':' && i+2 < len(runes) && runes[i+1] == ':' && runes[i+2] == '=':
+			tokens = append(tokens, grammarToken{grammarDefine, "::=", line})
+			i += 3
+		case ch == '-' && i+1 < len(runes) && runes[i+1] == '>':
+			tokens = append(tokens, grammarToken{grammarDefine, "->", line})
+			i += 2
+		case ch == '|':
+			tokens = append(tokens, grammarToken{grammarBar, "|", line})
+			i++
+		case ch == ';':
+			tokens = append(tokens, grammarToken{grammarEnd, ";", line})
+			i++
+		case unicode.IsLetter(ch) || ch == '_':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_' || runes[end] == '\'') && runes[end] != 'ε' {
+				end++
+			}
+			tokens = append(tokens, grammarToken{grammarIdentifier, string(runes[i:end]), line})
+			i = end
+		default:
+			return nil, fmt.Errorf("linia %d: caracter neașteptat '%c'", line, ch)
+		}
+	}
+
+	return tokens, nil
+}
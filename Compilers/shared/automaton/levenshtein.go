@@ -0,0 +1,88 @@
+package automaton
+
+import "fmt"
+
+// LevenshteinNFA builds an NFA accepting exactly the words at edit distance
+// at most k from word, over alphabet extended with the symbols of word.
+// State "qi_e" means that i symbols of word have been matched using e edits.
+// The usual construction has ε-transitions for deletions; here deletions are
+// folded into the following symbol and into the final states, so the NFA has
+// none: qi_e is final when the n-i remaining symbols of word can still be
+// deleted, i.e. n-i+e <= k.
+func LevenshteinNFA(word string, k int, alphabet []string) (*FiniteAutomaton, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("distanța maximă nu poate fi negativă")
+	}
+
+	symbols := splitSymbols(word)
+	n := len(symbols)
+
+	fa := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     append([]string{}, alphabet...),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: levenshteinState(0, 0),
+		FinalStates:  []string{},
+	}
+	for _, symbol := range symbols {
+		if !contains(fa.Alphabet, symbol) {
+			fa.Alphabet = append(fa.Alphabet, symbol)
+		}
+	}
+
+	for e := 0; e <= k; e++ {
+		for i := 0; i <= n; i++ {
+			name := levenshteinState(i, e)
+			fa.States = append(fa.States, name)
+			if n-i+e <= k {
+				fa.FinalStates = append(fa.FinalStates, name)
+			}
+		}
+	}
+
+	for e := 0; e <= k; e++ {
+		for i := 0; i <= n; i++ {
+			from := levenshteinState(i, e)
+			for _, symbol := range fa.Alphabet {
+				// Delete d symbols of word first, then read symbol.
+				for d := 0; e+d <= k && i+d <= n; d++ {
+					j, cost := i+d, e+d
+					if j < n && symbols[j] == symbol {
+						addTransitionUnchecked(fa, from, symbol, levenshteinState(j+1, cost))
+					}
+					if cost < k {
+						addTransitionUnchecked(fa, from, symbol, levenshteinState(j, cost+1)) // insertion
+						if j < n {
+							addTransitionUnchecked(fa, from, symbol, levenshteinState(j+1, cost+1)) // substitution
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return fa, nil
+}
+
+func levenshteinState(i, e int) string {
+	return fmt.Sprintf("q%d_%d", i, e)
+}
+
+// ApproxAccepts reports whether fa accepts some word at edit distance at most
+// k from input, by intersecting fa with the Levenshtein NFA of input.
+func ApproxAccepts(fa *FiniteAutomaton, input string, k int) bool {
+	_, found := ApproxMatch(fa, input, k)
+	return found
+}
+
+// ApproxMatch returns a word accepted by fa at edit distance at most k from
+// input: a shortest word of the intersection, not necessarily the closest
+// one. Calling it for k = 0, 1, ... finds a closest word; Repair computes the
+// closest word and the edit operations directly.
+func ApproxMatch(fa *FiniteAutomaton, input string, k int) (string, bool) {
+	levenshtein, err := LevenshteinNFA(input, k, fa.Alphabet)
+	if err != nil {
+		return "", false
+	}
+	return Intersection(fa, levenshtein).ShortestWord()
+}
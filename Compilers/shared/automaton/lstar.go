@@ -0,0 +1,359 @@
+package automaton
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// LStarMaxStates stops the learner when the target does not look regular.
+const LStarMaxStates = 1000
+
+// MembershipOracle answers whether a word belongs to the unknown language.
+type MembershipOracle func(word string) bool
+
+// EquivalenceOracle checks a hypothesis and returns a word on which it is
+// wrong, or false when no such word was found.
+type EquivalenceOracle interface {
+	FindCounterexample(hypothesis *FiniteAutomaton) (string, bool)
+}
+
+// ReferenceOracle compares hypotheses with a known automaton and always finds
+// a shortest counterexample.
+type ReferenceOracle struct {
+	Target *FiniteAutomaton
+}
+
+// RandomTestingOracle compares hypotheses with the membership oracle on
+// random words of at most MaxLength symbols. Rand is kept across queries, so
+// every query draws new words; when nil, a generator with seed 1 is created
+// on first use.
+type RandomTestingOracle struct {
+	Membership MembershipOracle
+	Alphabet   []string
+	Samples    int
+	MaxLength  int
+	Rand       *rand.Rand
+}
+
+// NewRandomTestingOracle checks the sampling parameters: random words need
+// at least one symbol to be drawn from.
+func NewRandomTestingOracle(membership MembershipOracle, alphabet []string, samples, maxLength int) (*RandomTestingOracle, error) {
+	if len(alphabet) == 0 {
+		return nil, fmt.Errorf("alfabetul trebuie să conțină cel puțin un simbol")
+	}
+	if samples < 0 || maxLength < 0 {
+		return nil, fmt.Errorf("numărul de teste și lungimea maximă nu pot fi negative")
+	}
+	return &RandomTestingOracle{
+		Membership: membership,
+		Alphabet:   append([]string{}, alphabet...),
+		Samples:    samples,
+		MaxLength:  maxLength,
+		Rand:       rand.New(rand.NewSource(1)),
+	}, nil
+}
+
+// ObservationTable is a snapshot of the L* table: Rows[s][i] tells whether
+// s followed by Suffixes[i] is in the language, for every s in Prefixes and
+// Boundary.
+type ObservationTable struct {
+	Prefixes []string          `json:"prefixes"`
+	Boundary []string          `json:"boundary"`
+	Suffixes []string          `json:"suffixes"`
+	Rows     map[string][]bool `json:"rows"`
+}
+
+// LStarRound records one hypothesis and the equivalence query answer for it.
+type LStarRound struct {
+	Table            ObservationTable `json:"table"`
+	HypothesisStates int              `json:"hypothesisStates"`
+	Counterexample   string           `json:"counterexample"`
+	Correct          bool             `json:"correct"`
+}
+
+type LStarResult struct {
+	Automaton         *FiniteAutomaton `json:"automaton"`
+	History           []LStarRound     `json:"history"`
+	MembershipQueries int              `json:"membershipQueries"`
+}
+
+func (o *ReferenceOracle) FindCounterexample(hypothesis *FiniteAutomaton) (string, bool) {
+	type pair struct {
+		hypothesis map[string]bool
+		target     map[string]bool
+		word       string
+	}
+
+	hypothesis = hypothesis.withoutEpsilon()
+	target := o.Target.withoutEpsilon()
+
+	alphabet := append([]string{}, hypothesis.Alphabet...)
+	for _, symbol := range target.Alphabet {
+		if !contains(alphabet, symbol) {
+			alphabet = append(alphabet, symbol)
+		}
+	}
+
+	start := pair{
+		hypothesis: map[string]bool{hypothesis.InitialState: true},
+		target:     map[string]bool{target.InitialState: true},
+	}
+	queue := []pair{start}
+	visited := map[string]bool{}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		key := stateSetKey(current.hypothesis) + "|" + stateSetKey(current.target)
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+
+		if hypothesis.anyFinal(current.hypothesis) != target.anyFinal(current.target) {
+			return current.word, true
+		}
+
+		for _, symbol := range alphabet {
+			queue = append(queue, pair{
+				hypothesis: hypothesis.nextStates(current.hypothesis, symbol),
+				target:     target.nextStates(current.target, symbol),
+				word:       current.word + symbol,
+			})
+		}
+	}
+
+	return "", false
+}
+
+func (o *RandomTestingOracle) FindCounterexample(hypothesis *FiniteAutomaton) (string, bool) {
+	if o.Rand == nil {
+		o.Rand = rand.New(rand.NewSource(1))
+	}
+	random := o.Rand
+
+	// Without symbols (or length) the empty word is the only one to try.
+	if len(o.Alphabet) == 0 || o.MaxLength <= 0 {
+		if o.Samples > 0 && o.Membership("") != hypothesis.Simulate("").Accepted {
+			return "", true
+		}
+		return "", false
+	}
+
+	for i := 0; i < o.Samples; i++ {
+		var sb strings.Builder
+		length := random.Intn(o.MaxLength + 1)
+		for j := 0; j < length; j++ {
+			sb.WriteString(o.Alphabet[random.Intn(len(o.Alphabet))])
+		}
+
+		word := sb.String()
+		if o.Membership(word) != hypothesis.Simulate(word).Accepted {
+			return word, true
+		}
+	}
+
+	return "", false
+}
+
+// nextStates returns the states reachable from states by reading symbol.
+func (fa *FiniteAutomaton) nextStates(states map[string]bool, symbol string) map[string]bool {
+	next := make(map[string]bool)
+	for state := range states {
+		for _, to := range fa.Transitions[state][symbol] {
+			next[to] = true
+		}
+	}
+	return next
+}
+
+func (fa *FiniteAutomaton) anyFinal(states map[string]bool) bool {
+	for state := range states {
+		if fa.IsFinalState(state) {
+			return true
+		}
+	}
+	return false
+}
+
+func stateSetKey(states map[string]bool) string {
+	keys := getKeys(states)
+	sort.Strings(keys)
+	return strings.Join(keys, "\x00")
+}
+
+type lstarLearner struct {
+	alphabet   []string
+	membership MembershipOracle
+	cache      map[string]bool
+	prefixes   []string
+	suffixes   []string
+}
+
+func (l *lstarLearner) member(word string) bool {
+	if result, exists := l.cache[word]; exists {
+		return result
+	}
+	result := l.membership(word)
+	l.cache[word] = result
+	return result
+}
+
+func (l *lstarLearner) row(prefix string) []bool {
+	row := make([]bool, len(l.suffixes))
+	for i, suffix := range l.suffixes {
+		row[i] = l.member(prefix + suffix)
+	}
+	return row
+}
+
+func rowKey(row []bool) string {
+	var sb strings.Builder
+	for _, value := range row {
+		if value {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+// close extends the prefixes until every boundary row equals some prefix row.
+func (l *lstarLearner) close() error {
+	for {
+		known := make(map[string]bool)
+		for _, prefix := range l.prefixes {
+			known[rowKey(l.row(prefix))] = true
+		}
+
+		added := false
+		for _, prefix := range l.prefixes {
+			for _, symbol := range l.alphabet {
+				extended := prefix + symbol
+				key := rowKey(l.row(extended))
+				if !known[key] {
+					l.prefixes = append(l.prefixes, extended)
+					known[key] = true
+					added = true
+				}
+			}
+		}
+
+		if !added {
+			return nil
+		}
+
+		if len(l.prefixes) > LStarMaxStates {
+			return fmt.Errorf("limbajul pare să nu fie regulat: ipoteza depășește %d stări", LStarMaxStates)
+		}
+	}
+}
+
+func (l *lstarLearner) snapshot() ObservationTable {
+	table := ObservationTable{
+		Prefixes: append([]string{}, l.prefixes...),
+		Boundary: []string{},
+		Suffixes: append([]string{}, l.suffixes...),
+		Rows:     make(map[string][]bool),
+	}
+
+	for _, prefix := range l.prefixes {
+		table.Rows[prefix] = l.row(prefix)
+	}
+	for _, prefix := range l.prefixes {
+		for _, symbol := range l.alphabet {
+			extended := prefix + symbol
+			if _, exists := table.Rows[extended]; !exists {
+				table.Boundary = append(table.Boundary, extended)
+				table.Rows[extended] = l.row(extended)
+			}
+		}
+	}
+
+	return table
+}
+
+// hypothesis builds the DFA whose states are the distinct prefix rows. The
+// prefixes always have pairwise distinct rows, so there is one state per prefix.
+func (l *lstarLearner) hypothesis() *FiniteAutomaton {
+	stateOf := make(map[string]string)
+	fa := &FiniteAutomaton{
+		States:      []string{},
+		Alphabet:    append([]string{}, l.alphabet...),
+		Transitions: make(map[string]map[string][]string),
+		FinalStates: []string{},
+	}
+
+	for i, prefix := range l.prefixes {
+		name := fmt.Sprintf("q%d", i)
+		stateOf[rowKey(l.row(prefix))] = name
+		fa.States = append(fa.States, name)
+		if l.member(prefix) {
+			fa.FinalStates = append(fa.FinalStates, name)
+		}
+	}
+	fa.InitialState = stateOf[rowKey(l.row(""))]
+
+	for i, prefix := range l.prefixes {
+		name := fa.States[i]
+		fa.Transitions[name] = make(map[string][]string)
+		for _, symbol := range l.alphabet {
+			fa.Transitions[name][symbol] = []string{stateOf[rowKey(l.row(prefix+symbol))]}
+		}
+	}
+
+	return fa
+}
+
+// LStar learns the minimal complete DFA of an unknown regular language from
+// membership and equivalence queries (Angluin's L*). Counterexamples are
+// handled as in Maler and Pnueli: all their suffixes become columns, which
+// keeps the table consistent, so only closedness has to be restored.
+func LStar(alphabet []string, membership MembershipOracle, equivalence EquivalenceOracle) (*LStarResult, error) {
+	if len(alphabet) == 0 {
+		return nil, fmt.Errorf("alfabetul trebuie să conțină cel puțin un simbol")
+	}
+
+	learner := &lstarLearner{
+		alphabet:   append([]string{}, alphabet...),
+		membership: membership,
+		cache:      make(map[string]bool),
+		prefixes:   []string{""},
+		suffixes:   []string{""},
+	}
+	result := &LStarResult{History: []LStarRound{}}
+
+	for {
+		if err := learner.close(); err != nil {
+			return nil, err
+		}
+
+		hypothesis := learner.hypothesis()
+		counterexample, found := equivalence.FindCounterexample(hypothesis)
+
+		result.History = append(result.History, LStarRound{
+			Table:            learner.snapshot(),
+			HypothesisStates: len(hypothesis.States),
+			Counterexample:   counterexample,
+			Correct:          !found,
+		})
+
+		if !found {
+			result.Automaton = hypothesis
+			result.MembershipQueries = len(learner.cache)
+			return result, nil
+		}
+
+		runes := []rune(counterexample)
+		for i := range runes {
+			suffix := string(runes[i:])
+			if !contains(learner.suffixes, suffix) {
+				learner.suffixes = append(learner.suffixes, suffix)
+			}
+		}
+	}
+}
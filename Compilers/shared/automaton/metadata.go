@@ -0,0 +1,109 @@
+package automaton
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatVersion is the current version of the automaton file format.
+// Version 1 files have no "version" field and no metadata or tests; they are
+// migrated when parsed and saved as version 2.
+const FormatVersion = 2
+
+const (
+	ExpectAccept = "accept"
+	ExpectReject = "reject"
+)
+
+// EmbeddedTest is an expected verdict stored in the automaton file.
+type EmbeddedTest struct {
+	Input  string `json:"input"`
+	Expect string `json:"expect"` // "accept" or "reject"
+}
+
+// TestFailure is an embedded test whose verdict differs from the expected one.
+// Error explains a rejection and is nil for an unexpected acceptance.
+type TestFailure struct {
+	Test     EmbeddedTest     `json:"test"`
+	Accepted bool             `json:"accepted"`
+	Error    *SimulationError `json:"error,omitempty"`
+}
+
+type TestReport struct {
+	Total    int           `json:"total"`
+	Passed   int           `json:"passed"`
+	Failures []TestFailure `json:"failures"`
+}
+
+// migrate upgrades an automaton read from an older file format in place.
+func (fa *FiniteAutomaton) migrate() error {
+	switch {
+	case fa.Version == 0:
+		fa.Version = 1
+		fallthrough
+	case fa.Version == 1:
+		// Version 2 only adds optional fields.
+		fa.Version = 2
+	case fa.Version > FormatVersion:
+		return fmt.Errorf("versiunea %d a formatului nu este suportată (cel mult %d)", fa.Version, FormatVersion)
+	}
+
+	return nil
+}
+
+func (fa *FiniteAutomaton) validateTests() error {
+	return validateEmbeddedTests(fa.Tests)
+}
+
+func validateEmbeddedTests(tests []EmbeddedTest) error {
+	for i, test := range tests {
+		if test.Expect != ExpectAccept && test.Expect != ExpectReject {
+			return fmt.Errorf("testul %d ('%s') are rezultatul așteptat '%s' (se așteaptă %s sau %s)",
+				i+1, test.Input, test.Expect, ExpectAccept, ExpectReject)
+		}
+	}
+	return nil
+}
+
+// RunEmbeddedTests simulates every embedded test and reports the ones whose
+// verdict differs from the expected one.
+func (fa *FiniteAutomaton) RunEmbeddedTests() TestReport {
+	return runEmbeddedTests(fa.Tests, fa.Simulate)
+}
+
+func runEmbeddedTests(tests []EmbeddedTest, simulate func(input string) SimulationResult) TestReport {
+	report := TestReport{Total: len(tests), Failures: []TestFailure{}}
+
+	for _, test := range tests {
+		result := simulate(test.Input)
+		if result.Accepted == (test.Expect == ExpectAccept) {
+			report.Passed++
+			continue
+		}
+
+		report.Failures = append(report.Failures, TestFailure{
+			Test:     test,
+			Accepted: result.Accepted,
+			Error:    result.Error,
+		})
+	}
+
+	return report
+}
+
+func (r TestReport) String() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("Teste: %d/%d trecute\n", r.Passed, r.Total))
+	for _, failure := range r.Failures {
+		if failure.Accepted {
+			sb.WriteString(fmt.Sprintf("  ✗ '%s': acceptată, se aștepta respingerea\n", failure.Test.Input))
+		} else if failure.Error != nil {
+			sb.WriteString(fmt.Sprintf("  ✗ '%s': respinsă, se aștepta acceptarea (%s)\n", failure.Test.Input, failure.Error.Message))
+		} else {
+			sb.WriteString(fmt.Sprintf("  ✗ '%s': respinsă, se aștepta acceptarea\n", failure.Test.Input))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,232 @@
+package automaton
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PairEntry is one cell of the table-filling algorithm. A distinguishable
+// pair records the round it was marked in and a shortest suffix accepted from
+// exactly one of the two states, AcceptedFrom. Round 0 marks the pairs of a
+// final and a non-final state, whose suffix is the empty word; a pair marked
+// in round k has a distinguishing suffix of length k.
+type PairEntry struct {
+	P               string `json:"p"`
+	Q               string `json:"q"`
+	Distinguishable bool   `json:"distinguishable"`
+	Round           int    `json:"round"`
+	Suffix          string `json:"suffix"`
+	AcceptedFrom    string `json:"acceptedFrom,omitempty"`
+}
+
+// DistinguishabilityTable holds every pair of distinct states, p before q in
+// the order of States.
+type DistinguishabilityTable struct {
+	States []string    `json:"states"`
+	Pairs  []PairEntry `json:"pairs"`
+	Rounds int         `json:"rounds"`
+
+	index map[string]int
+}
+
+// TableFilling runs the Myhill–Nerode table-filling algorithm on a DFA.
+// A partial automaton is completed first with a sink state, which then
+// appears in the table. Two states end up unmarked exactly when they accept
+// the same words, i.e. when the minimal automaton merges them.
+func (fa *FiniteAutomaton) TableFilling() (*DistinguishabilityTable, error) {
+	if !fa.IsDeterministic() {
+		return nil, fmt.Errorf("tabelul de distingere necesită un automat determinist")
+	}
+
+	states := append([]string{}, fa.States...)
+	used := make(map[string]bool)
+	for _, state := range states {
+		used[state] = true
+	}
+	sink := ""
+	for _, state := range fa.States {
+		for _, symbol := range fa.Alphabet {
+			if sink == "" && len(fa.Transitions[state][symbol]) == 0 {
+				sink = freshName("∅", used)
+				states = append(states, sink)
+			}
+		}
+	}
+
+	table := &DistinguishabilityTable{States: states, index: make(map[string]int)}
+	for i, state := range states {
+		table.index[state] = i
+	}
+	next := func(state, symbol string) int {
+		if targets := fa.Transitions[state][symbol]; len(targets) > 0 {
+			return table.index[targets[0]]
+		}
+		return table.index[sink]
+	}
+
+	n := len(states)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			entry := PairEntry{P: states[i], Q: states[j]}
+			finalP, finalQ := fa.IsFinalState(states[i]), fa.IsFinalState(states[j])
+			if finalP != finalQ {
+				entry.Distinguishable = true
+				entry.AcceptedFrom = states[i]
+				if finalQ {
+					entry.AcceptedFrom = states[j]
+				}
+			}
+			table.Pairs = append(table.Pairs, entry)
+		}
+	}
+
+	// A pair is marked in round k when some symbol leads it to a pair marked
+	// in round k-1; only marks of earlier rounds count, so the rounds match
+	// the lengths of the shortest distinguishing suffixes.
+	for round := 1; ; round++ {
+		marked := []int{}
+		suffixes := []PairEntry{}
+		for k, entry := range table.Pairs {
+			if entry.Distinguishable {
+				continue
+			}
+			for _, symbol := range fa.Alphabet {
+				p, q := next(entry.P, symbol), next(entry.Q, symbol)
+				if p == q {
+					continue
+				}
+				target := table.Pairs[table.pairIndex(p, q)]
+				if !target.Distinguishable || target.Round != round-1 {
+					continue
+				}
+				entry.Suffix = symbol + target.Suffix
+				entry.AcceptedFrom = entry.P
+				if target.AcceptedFrom == states[q] {
+					entry.AcceptedFrom = entry.Q
+				}
+				marked = append(marked, k)
+				suffixes = append(suffixes, entry)
+				break
+			}
+		}
+		if len(marked) == 0 {
+			table.Rounds = round - 1
+			break
+		}
+		for i, k := range marked {
+			table.Pairs[k] = suffixes[i]
+			table.Pairs[k].Distinguishable = true
+			table.Pairs[k].Round = round
+		}
+	}
+
+	return table, nil
+}
+
+// pairIndex is the position in Pairs of the pair of the i-th and j-th states.
+func (t *DistinguishabilityTable) pairIndex(i, j int) int {
+	if i > j {
+		i, j = j, i
+	}
+	n := len(t.States)
+	return i*(2*n-i-1)/2 + j - i - 1
+}
+
+// Lookup returns the entry of the pair p, q in either order.
+func (t *DistinguishabilityTable) Lookup(p, q string) (PairEntry, bool) {
+	i, okP := t.index[p]
+	j, okQ := t.index[q]
+	if !okP || !okQ || i == j {
+		return PairEntry{}, false
+	}
+	return t.Pairs[t.pairIndex(i, j)], true
+}
+
+// EquivalenceClasses groups the states no suffix distinguishes, in the order
+// of States.
+func (t *DistinguishabilityTable) EquivalenceClasses() [][]string {
+	classes := [][]string{}
+	assigned := make(map[int]bool)
+	for i, state := range t.States {
+		if assigned[i] {
+			continue
+		}
+		class := []string{state}
+		for j := i + 1; j < len(t.States); j++ {
+			if !assigned[j] && !t.Pairs[t.pairIndex(i, j)].Distinguishable {
+				class = append(class, t.States[j])
+				assigned[j] = true
+			}
+		}
+		classes = append(classes, class)
+	}
+	return classes
+}
+
+// String draws the lower triangle of the table, a cell holding the
+// distinguishing suffix and its round or "≡" for equivalent states, followed
+// by the explanation of every marked pair and the equivalence classes.
+func (t *DistinguishabilityTable) String() string {
+	var sb strings.Builder
+	sb.WriteString("=== Tabelul de distingere (Myhill–Nerode) ===\n\n")
+
+	n := len(t.States)
+	if n < 2 {
+		sb.WriteString("Automatul are o singură stare.\n")
+		return sb.String()
+	}
+
+	cell := func(entry PairEntry) string {
+		if !entry.Distinguishable {
+			return "≡"
+		}
+		return fmt.Sprintf("%s (%d)", displaySuffix(entry.Suffix), entry.Round)
+	}
+
+	width := 0
+	for _, state := range t.States {
+		width = max(width, len([]rune(state)))
+	}
+	for _, entry := range t.Pairs {
+		width = max(width, len([]rune(cell(entry))))
+	}
+	pad := func(s string) string {
+		return s + strings.Repeat(" ", width-len([]rune(s)))
+	}
+
+	for i := 1; i < n; i++ {
+		row := pad(t.States[i])
+		for j := 0; j < i; j++ {
+			row += " │ " + pad(cell(t.Pairs[t.pairIndex(j, i)]))
+		}
+		sb.WriteString(strings.TrimRight(row, " ") + "\n")
+	}
+	footer := pad("")
+	for j := 0; j < n-1; j++ {
+		footer += "   " + pad(t.States[j])
+	}
+	sb.WriteString(strings.TrimRight(footer, " ") + "\n\n")
+
+	for _, entry := range t.Pairs {
+		if !entry.Distinguishable {
+			continue
+		}
+		other := entry.P
+		if other == entry.AcceptedFrom {
+			other = entry.Q
+		}
+		sb.WriteString(fmt.Sprintf("%s ≢ %s (runda %d): '%s' este acceptat din %s, respins din %s\n",
+			entry.P, entry.Q, entry.Round, displaySuffix(entry.Suffix), entry.AcceptedFrom, other))
+	}
+
+	classes := t.EquivalenceClasses()
+	sb.WriteString(fmt.Sprintf("\nClase de echivalență (%d): %s\n", len(classes), partitionString(classes)))
+	return sb.String()
+}
+
+func displaySuffix(suffix string) string {
+	if suffix == "" {
+		return Epsilon
+	}
+	return suffix
+}
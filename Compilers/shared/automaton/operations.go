@@ -0,0 +1,501 @@
+package automaton
+
+import (
+	"fmt"
+	"sort"
+)
+
+// pairName names the product state of p and q.
+func pairName(p, q string) string {
+	return "(" + p + "," + q + ")"
+}
+
+// unionAlphabet lists the symbols of a followed by those only in b.
+func unionAlphabet(a, b []string) []string {
+	alphabet := append([]string{}, a...)
+	for _, symbol := range b {
+		if !contains(alphabet, symbol) {
+			alphabet = append(alphabet, symbol)
+		}
+	}
+	return alphabet
+}
+
+// Intersection builds the product automaton accepting L(a) ∩ L(b), over the
+// union of the alphabets (only shared symbols label transitions). Only pairs
+// reachable from the pair of initial states are created; states are named
+// "(p,q)". Nondeterminism is preserved.
+func Intersection(a, b *FiniteAutomaton) *FiniteAutomaton {
+	return intersection(a, b, nil)
+}
+
+func intersection(a, b *FiniteAutomaton, trace *ConstructionTrace) *FiniteAutomaton {
+	a, b = a.withoutEpsilon(), b.withoutEpsilon()
+	product := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     unionAlphabet(a.Alphabet, b.Alphabet),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: pairName(a.InitialState, b.InitialState),
+		FinalStates:  []string{},
+	}
+
+	type pair struct{ p, q string }
+	start := pair{a.InitialState, b.InitialState}
+	seen := map[pair]bool{start: true}
+	queue := []pair{start}
+	trace.add(ConstructionStep{
+		Action:  ConstructionNewState,
+		Message: fmt.Sprintf("Starea inițială este %s", product.InitialState),
+		State:   product.InitialState,
+		Members: []string{start.p, start.q},
+	})
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		name := pairName(current.p, current.q)
+		if trace != nil {
+			waiting := make([]string, len(queue))
+			for i, next := range queue {
+				waiting[i] = pairName(next.p, next.q)
+			}
+			trace.add(ConstructionStep{
+				Action:   ConstructionPop,
+				Message:  fmt.Sprintf("Se prelucrează %s (rămase: %d)", name, len(queue)),
+				State:    name,
+				Members:  []string{current.p, current.q},
+				Worklist: waiting,
+			})
+		}
+
+		product.States = append(product.States, name)
+		if a.IsFinalState(current.p) && b.IsFinalState(current.q) {
+			product.FinalStates = append(product.FinalStates, name)
+			trace.add(ConstructionStep{
+				Action:  ConstructionFinal,
+				Message: fmt.Sprintf("%s este finală: %s și %s sunt finale", name, current.p, current.q),
+				State:   name,
+			})
+		}
+
+		for _, symbol := range product.Alphabet {
+			for _, p := range a.Transitions[current.p][symbol] {
+				for _, q := range b.Transitions[current.q][symbol] {
+					next := pair{p, q}
+					if !seen[next] {
+						seen[next] = true
+						queue = append(queue, next)
+						trace.add(ConstructionStep{
+							Action:  ConstructionNewState,
+							Message: fmt.Sprintf("Pereche nouă %s, adăugată în coadă", pairName(p, q)),
+							State:   pairName(p, q),
+							Members: []string{p, q},
+						})
+					}
+					addTransitionUnchecked(product, name, symbol, pairName(p, q))
+					trace.add(ConstructionStep{
+						Action:  ConstructionTransition,
+						Message: fmt.Sprintf("δ(%s, %s) ∋ %s", name, symbol, pairName(p, q)),
+						State:   name,
+						Symbol:  symbol,
+						Target:  pairName(p, q),
+					})
+				}
+			}
+		}
+	}
+
+	trace.add(ConstructionStep{
+		Action:  ConstructionDone,
+		Message: fmt.Sprintf("Coada este goală: %d perechi accesibile", len(product.States)),
+	})
+	return product
+}
+
+// ShortestWord returns a shortest accepted word, the first in alphabet order
+// among those of minimal length, or false when the language is empty.
+func (fa *FiniteAutomaton) ShortestWord() (string, bool) {
+	if fa.HasEpsilonTransitions() {
+		return fa.RemoveEpsilon().ShortestWord()
+	}
+
+	type visit struct {
+		parent string
+		symbol string
+	}
+
+	visited := map[string]visit{fa.InitialState: {}}
+	queue := []string{fa.InitialState}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if fa.IsFinalState(current) {
+			word := ""
+			for current != fa.InitialState {
+				v := visited[current]
+				word = v.symbol + word
+				current = v.parent
+			}
+			return word, true
+		}
+
+		for _, symbol := range fa.Alphabet {
+			for _, next := range fa.Transitions[current][symbol] {
+				if _, seen := visited[next]; !seen {
+					visited[next] = visit{parent: current, symbol: symbol}
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+// IsEmpty reports whether the automaton accepts no word.
+func (fa *FiniteAutomaton) IsEmpty() bool {
+	_, found := fa.ShortestWord()
+	return !found
+}
+
+// structure copies states, alphabet, transitions, initial and final states,
+// leaving out positions and metadata, which do not carry over to the result
+// of an operation.
+func (fa *FiniteAutomaton) structure() *FiniteAutomaton {
+	result := &FiniteAutomaton{
+		States:       append([]string{}, fa.States...),
+		Alphabet:     append([]string{}, fa.Alphabet...),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: fa.InitialState,
+		FinalStates:  append([]string{}, fa.FinalStates...),
+	}
+	for _, t := range fa.TransitionList() {
+		addTransitionUnchecked(result, t.From, t.Symbol, t.To)
+	}
+	return result
+}
+
+// reachableStates lists the states reachable from start, in breadth-first
+// order, following ε-transitions as well.
+func (fa *FiniteAutomaton) reachableStates(start ...string) []string {
+	seen := make(map[string]bool)
+	order := []string{}
+	for _, state := range start {
+		if !seen[state] {
+			seen[state] = true
+			order = append(order, state)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for _, symbol := range orderedKeys(fa.Alphabet, fa.Transitions[order[i]]) {
+			for _, next := range fa.Transitions[order[i]][symbol] {
+				if !seen[next] {
+					seen[next] = true
+					order = append(order, next)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// coReachable reports, for every state, whether a final state can be reached from it.
+func (fa *FiniteAutomaton) coReachable() map[string]bool {
+	reverse := make(map[string][]string)
+	for _, t := range fa.TransitionList() {
+		reverse[t.To] = append(reverse[t.To], t.From)
+	}
+
+	alive := make(map[string]bool)
+	queue := []string{}
+	for _, state := range fa.FinalStates {
+		if !alive[state] {
+			alive[state] = true
+			queue = append(queue, state)
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, previous := range reverse[current] {
+			if !alive[previous] {
+				alive[previous] = true
+				queue = append(queue, previous)
+			}
+		}
+	}
+	return alive
+}
+
+// trimmed keeps only the states reachable from the initial state.
+func (fa *FiniteAutomaton) trimmed() *FiniteAutomaton {
+	reachable := fa.reachableStates(fa.InitialState)
+	keep := make(map[string]bool)
+	for _, state := range reachable {
+		keep[state] = true
+	}
+
+	result := &FiniteAutomaton{
+		States:       reachable,
+		Alphabet:     append([]string{}, fa.Alphabet...),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: fa.InitialState,
+		FinalStates:  []string{},
+	}
+	for _, state := range fa.FinalStates {
+		if keep[state] {
+			result.FinalStates = append(result.FinalStates, state)
+		}
+	}
+	for _, t := range fa.TransitionList() {
+		if keep[t.From] {
+			addTransitionUnchecked(result, t.From, t.Symbol, t.To)
+		}
+	}
+	return result
+}
+
+// withInitialSet returns an automaton that starts in all of the given states
+// at once: a fresh initial state gets the outgoing transitions of each of
+// them, and is final when one of them is.
+func (fa *FiniteAutomaton) withInitialSet(states []string) *FiniteAutomaton {
+	result := fa.structure()
+
+	used := make(map[string]bool)
+	for _, state := range result.States {
+		used[state] = true
+	}
+	start := freshName("start", used)
+	result.States = append(result.States, start)
+	result.InitialState = start
+
+	for _, state := range states {
+		if fa.IsFinalState(state) && !result.IsFinalState(start) {
+			result.FinalStates = append(result.FinalStates, start)
+		}
+		for _, symbol := range fa.Alphabet {
+			for _, next := range fa.Transitions[state][symbol] {
+				addTransitionUnchecked(result, start, symbol, next)
+			}
+		}
+	}
+
+	return result.trimmed()
+}
+
+// RightQuotient accepts L(a)/L(b) = {x | xy ∈ L(a) for some y ∈ L(b)}: the
+// automaton a where a state is final when some word of L(b) leads from it to
+// a final state of a.
+func RightQuotient(a, b *FiniteAutomaton) *FiniteAutomaton {
+	result := a.structure()
+	result.FinalStates = []string{}
+	for _, state := range a.States {
+		from := a.structure()
+		from.InitialState = state
+		if !Intersection(from, b).IsEmpty() {
+			result.FinalStates = append(result.FinalStates, state)
+		}
+	}
+	return result.trimmed()
+}
+
+// LeftQuotient accepts L(b)\L(a) = {y | xy ∈ L(a) for some x ∈ L(b)}: the
+// automaton a started in every state that some word of L(b) reaches.
+func LeftQuotient(a, b *FiniteAutomaton) *FiniteAutomaton {
+	a, b = a.withoutEpsilon(), b.withoutEpsilon()
+	type pair struct{ p, q string }
+	start := pair{a.InitialState, b.InitialState}
+	seen := map[pair]bool{start: true}
+	queue := []pair{start}
+
+	starts := []string{}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if b.IsFinalState(current.q) && !contains(starts, current.p) {
+			starts = append(starts, current.p)
+		}
+		for _, symbol := range a.Alphabet {
+			for _, p := range a.Transitions[current.p][symbol] {
+				for _, q := range b.Transitions[current.q][symbol] {
+					if next := (pair{p, q}); !seen[next] {
+						seen[next] = true
+						queue = append(queue, next)
+					}
+				}
+			}
+		}
+	}
+
+	return a.withInitialSet(starts)
+}
+
+// PrefixClosure accepts every prefix of a word of L(fa): every reachable
+// state from which a final state can be reached becomes final.
+func PrefixClosure(fa *FiniteAutomaton) *FiniteAutomaton {
+	result := fa.trimmed()
+	alive := result.coReachable()
+	result.FinalStates = []string{}
+	for _, state := range result.States {
+		if alive[state] {
+			result.FinalStates = append(result.FinalStates, state)
+		}
+	}
+	return result
+}
+
+// SuffixClosure accepts every suffix of a word of L(fa): the automaton is
+// started in every reachable state.
+func SuffixClosure(fa *FiniteAutomaton) *FiniteAutomaton {
+	fa = fa.withoutEpsilon()
+	return fa.withInitialSet(fa.reachableStates(fa.InitialState))
+}
+
+// InfixClosure accepts every factor of a word of L(fa), as the prefixes of
+// its suffixes.
+func InfixClosure(fa *FiniteAutomaton) *FiniteAutomaton {
+	return PrefixClosure(SuffixClosure(fa))
+}
+
+// Homomorphism accepts h(L(fa)), where h maps every symbol of fa to a string;
+// the symbols of the images (one per character) form the new alphabet. A
+// transition on a becomes a path spelling h(a) through fresh states; images
+// that are empty become ε-transitions, which are then eliminated.
+func Homomorphism(fa *FiniteAutomaton, h map[string]string) (*FiniteAutomaton, error) {
+	result := &FiniteAutomaton{
+		States:       append([]string{}, fa.States...),
+		Alphabet:     []string{},
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: fa.InitialState,
+		FinalStates:  append([]string{}, fa.FinalStates...),
+	}
+	for _, symbol := range fa.Alphabet {
+		image, exists := h[symbol]
+		if !exists {
+			return nil, fmt.Errorf("omomorfismul nu este definit pentru simbolul '%s'", symbol)
+		}
+		for _, s := range splitSymbols(image) {
+			if !contains(result.Alphabet, s) {
+				result.Alphabet = append(result.Alphabet, s)
+			}
+		}
+	}
+	if len(result.Alphabet) == 0 {
+		return nil, fmt.Errorf("imaginea omomorfismului nu conține niciun simbol")
+	}
+
+	used := make(map[string]bool)
+	for _, state := range fa.States {
+		used[state] = true
+	}
+	for _, t := range fa.TransitionList() {
+		symbols := splitSymbols(h[t.Symbol])
+		if len(symbols) == 0 {
+			addTransitionUnchecked(result, t.From, Epsilon, t.To)
+			continue
+		}
+
+		from := t.From
+		for i, symbol := range symbols {
+			to := t.To
+			if i < len(symbols)-1 {
+				to = freshIndexedName(t.From+"_"+t.Symbol, used)
+				result.States = append(result.States, to)
+			}
+			addTransitionUnchecked(result, from, symbol, to)
+			from = to
+		}
+	}
+
+	return result.RemoveEpsilon().trimmed(), nil
+}
+
+// InverseHomomorphism accepts h⁻¹(L(fa)) = {w | h(w) ∈ L(fa)} over the
+// symbols h is defined for: p reads c to every state fa reaches from p by
+// reading h(c).
+func InverseHomomorphism(fa *FiniteAutomaton, h map[string]string) (*FiniteAutomaton, error) {
+	if len(h) == 0 {
+		return nil, fmt.Errorf("omomorfismul trebuie să fie definit pentru cel puțin un simbol")
+	}
+
+	alphabet := make([]string, 0, len(h))
+	for symbol := range h {
+		alphabet = append(alphabet, symbol)
+	}
+	sort.Strings(alphabet)
+
+	fa = fa.withoutEpsilon()
+	result := &FiniteAutomaton{
+		States:       append([]string{}, fa.States...),
+		Alphabet:     alphabet,
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: fa.InitialState,
+		FinalStates:  append([]string{}, fa.FinalStates...),
+	}
+	for _, state := range fa.States {
+		for _, symbol := range alphabet {
+			current := map[string]bool{state: true}
+			for _, s := range splitSymbols(h[symbol]) {
+				current = fa.nextStates(current, s)
+			}
+			for _, next := range fa.States {
+				if current[next] {
+					addTransitionUnchecked(result, state, symbol, next)
+				}
+			}
+		}
+	}
+
+	return result.trimmed(), nil
+}
+
+// Shuffle accepts the interleavings of a word of L(a) with a word of L(b):
+// in state (p,q) a symbol advances either a or b.
+func Shuffle(a, b *FiniteAutomaton) *FiniteAutomaton {
+	a, b = a.withoutEpsilon(), b.withoutEpsilon()
+	product := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     unionAlphabet(a.Alphabet, b.Alphabet),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: pairName(a.InitialState, b.InitialState),
+		FinalStates:  []string{},
+	}
+
+	type pair struct{ p, q string }
+	start := pair{a.InitialState, b.InitialState}
+	seen := map[pair]bool{start: true}
+	queue := []pair{start}
+	visit := func(from string, next pair, symbol string) {
+		addTransitionUnchecked(product, from, symbol, pairName(next.p, next.q))
+		if !seen[next] {
+			seen[next] = true
+			queue = append(queue, next)
+		}
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		name := pairName(current.p, current.q)
+		product.States = append(product.States, name)
+		if a.IsFinalState(current.p) && b.IsFinalState(current.q) {
+			product.FinalStates = append(product.FinalStates, name)
+		}
+
+		for _, symbol := range product.Alphabet {
+			for _, p := range a.Transitions[current.p][symbol] {
+				visit(name, pair{p, current.q}, symbol)
+			}
+			for _, q := range b.Transitions[current.q][symbol] {
+				visit(name, pair{current.p, q}, symbol)
+			}
+		}
+	}
+
+	return product
+}
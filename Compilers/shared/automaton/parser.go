@@ -6,14 +6,53 @@ import (
 	"os"
 )
 
+// AutomatonSymbolic is the "type" of a SymbolicAutomaton definition; finite
+// automata leave the field out.
+const AutomatonSymbolic = "symbolic"
+
+// jsonType reads the "type" field that tells the kinds of definitions apart.
+func jsonType(jsonStr string) (string, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal([]byte(jsonStr), &header); err != nil {
+		return "", fmt.Errorf("eroare la parsarea JSON: %v", err)
+	}
+	return header.Type, nil
+}
+
+// IsSymbolicJSON reports whether the definition declares "type": "symbolic"
+// and must be parsed with ParseSymbolicFromJSON.
+func IsSymbolicJSON(jsonStr string) bool {
+	kind, err := jsonType(jsonStr)
+	return err == nil && kind == AutomatonSymbolic
+}
+
 func ParseFromJSON(jsonStr string) (*FiniteAutomaton, error) {
 	var fa FiniteAutomaton
 
-	err := json.Unmarshal([]byte(jsonStr), &fa)
+	kind, err := jsonType(jsonStr)
+	if err != nil {
+		return nil, err
+	}
+	switch kind {
+	case "":
+	case AutomatonSymbolic:
+		return nil, fmt.Errorf("automatul este simbolic (\"type\": \"%s\") și se încarcă cu ParseSymbolicFromJSON", AutomatonSymbolic)
+	default:
+		return nil, fmt.Errorf("tipul '%s' nu descrie un automat finit", kind)
+	}
+
+	err = json.Unmarshal([]byte(jsonStr), &fa)
 	if err != nil {
 		return nil, fmt.Errorf("eroare la parsarea JSON: %v", err)
 	}
 
+	if err := fa.migrate(); err != nil {
+		return nil, err
+	}
+
 	if err := fa.Validate(); err != nil {
 		return nil, fmt.Errorf("automat invalid: %v", err)
 	}
@@ -30,8 +69,12 @@ func ParseFromFile(filename string) (*FiniteAutomaton, error) {
 	return ParseFromJSON(string(data))
 }
 
+// ToJSON always writes the current format version.
 func (fa *FiniteAutomaton) ToJSON() (string, error) {
-	data, err := json.MarshalIndent(fa, "", "  ")
+	versioned := *fa
+	versioned.Version = FormatVersion
+
+	data, err := json.MarshalIndent(versioned, "", "  ")
 	if err != nil {
 		return "", fmt.Errorf("eroare la serializarea JSON: %v", err)
 	}
@@ -51,3 +94,137 @@ func (fa *FiniteAutomaton) SaveToFile(filename string) error {
 
 	return nil
 }
+
+func ParseTransducerFromJSON(jsonStr string) (Transducer, error) {
+	var header struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal([]byte(jsonStr), &header); err != nil {
+		return nil, fmt.Errorf("eroare la parsarea JSON: %v", err)
+	}
+
+	var t Transducer
+	switch header.Type {
+	case TransducerMealy:
+		t = &MealyMachine{}
+	case TransducerMoore:
+		t = &MooreMachine{}
+	default:
+		return nil, fmt.Errorf("tip de traductor necunoscut '%s' (se așteaptă '%s' sau '%s')",
+			header.Type, TransducerMealy, TransducerMoore)
+	}
+
+	if err := json.Unmarshal([]byte(jsonStr), t); err != nil {
+		return nil, fmt.Errorf("eroare la parsarea JSON: %v", err)
+	}
+
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("traductor invalid: %v", err)
+	}
+
+	return t, nil
+}
+
+func ParseTransducerFromFile(filename string) (Transducer, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+
+	return ParseTransducerFromJSON(string(data))
+}
+
+func (m *MealyMachine) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("eroare la serializarea JSON: %v", err)
+	}
+	return string(data), nil
+}
+
+func (m *MooreMachine) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("eroare la serializarea JSON: %v", err)
+	}
+	return string(data), nil
+}
+
+func SaveTransducerToFile(t Transducer, filename string) error {
+	jsonStr, err := t.ToJSON()
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filename, []byte(jsonStr), 0644)
+	if err != nil {
+		return fmt.Errorf("eroare la scrierea fișierului: %v", err)
+	}
+
+	return nil
+}
+
+func ParsePushdownFromJSON(jsonStr string) (*PushdownAutomaton, error) {
+	var pda PushdownAutomaton
+
+	err := json.Unmarshal([]byte(jsonStr), &pda)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la parsarea JSON: %v", err)
+	}
+
+	if err := pda.Validate(); err != nil {
+		return nil, fmt.Errorf("automat invalid: %v", err)
+	}
+
+	return &pda, nil
+}
+
+func ParsePushdownFromFile(filename string) (*PushdownAutomaton, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+
+	return ParsePushdownFromJSON(string(data))
+}
+
+func (pda *PushdownAutomaton) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(pda, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("eroare la serializarea JSON: %v", err)
+	}
+	return string(data), nil
+}
+
+func ParseTuringFromJSON(jsonStr string) (*TuringMachine, error) {
+	var tm TuringMachine
+
+	err := json.Unmarshal([]byte(jsonStr), &tm)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la parsarea JSON: %v", err)
+	}
+
+	if err := tm.Validate(); err != nil {
+		return nil, fmt.Errorf("mașină invalidă: %v", err)
+	}
+
+	return &tm, nil
+}
+
+func ParseTuringFromFile(filename string) (*TuringMachine, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+
+	return ParseTuringFromJSON(string(data))
+}
+
+func (tm *TuringMachine) ToJSON() (string, error) {
+	data, err := json.MarshalIndent(tm, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("eroare la serializarea JSON: %v", err)
+	}
+	return string(data), nil
+}
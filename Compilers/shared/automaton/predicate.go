@@ -0,0 +1,195 @@
+package automaton
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"unicode"
+)
+
+// RuneRange is the closed interval of runes Lo..Hi.
+type RuneRange struct {
+	Lo rune
+	Hi rune
+}
+
+// RunePredicate is a set of runes, kept as sorted, disjoint and non-adjacent
+// ranges, so equal sets have equal ranges and intersection, union and
+// complement are exact. It labels the transitions of a SymbolicAutomaton.
+type RunePredicate struct {
+	ranges []RuneRange
+}
+
+// ParsePredicate reads a predicate written as a one-character regular
+// expression in Go syntax: a literal ("a"), a class ("[a-zA-Z_]",
+// "[^0-9]"), a Unicode category ("\pL", "[\p{L}\p{Nd}_]") or "." for any
+// rune.
+func ParsePredicate(label string) (RunePredicate, error) {
+	re, err := syntax.Parse(label, syntax.Perl|syntax.DotNL)
+	if err != nil {
+		return RunePredicate{}, fmt.Errorf("predicatul '%s' este invalid: %v", label, err)
+	}
+	re = re.Simplify()
+
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return RunePredicate{}, nil
+	case syntax.OpAnyChar:
+		return AnyRune(), nil
+	case syntax.OpAnyCharNotNL:
+		return AnyRune().And(RuneSet('\n').Not()), nil
+	case syntax.OpCharClass:
+		return predicateFromPairs(re.Rune), nil
+	case syntax.OpLiteral:
+		if len(re.Rune) == 1 {
+			p := RuneSet(re.Rune[0])
+			if re.Flags&syntax.FoldCase != 0 {
+				for r := unicode.SimpleFold(re.Rune[0]); r != re.Rune[0]; r = unicode.SimpleFold(r) {
+					p = p.Or(RuneSet(r))
+				}
+			}
+			return p, nil
+		}
+	}
+	return RunePredicate{}, fmt.Errorf("predicatul '%s' trebuie să descrie un singur caracter", label)
+}
+
+// predicateFromPairs builds a predicate from lo, hi pairs in any order.
+func predicateFromPairs(pairs []rune) RunePredicate {
+	ranges := make([]RuneRange, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		ranges = append(ranges, RuneRange{pairs[i], pairs[i+1]})
+	}
+	return normalizeRanges(ranges)
+}
+
+func normalizeRanges(ranges []RuneRange) RunePredicate {
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i].Lo < ranges[j].Lo })
+	merged := []RuneRange{}
+	for _, r := range ranges {
+		if r.Lo > r.Hi {
+			continue
+		}
+		if n := len(merged); n > 0 && r.Lo <= merged[n-1].Hi+1 {
+			merged[n-1].Hi = max(merged[n-1].Hi, r.Hi)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return RunePredicate{ranges: merged}
+}
+
+// AnyRune is the predicate true for every rune.
+func AnyRune() RunePredicate {
+	return RunePredicate{ranges: []RuneRange{{0, unicode.MaxRune}}}
+}
+
+// RuneSet is the predicate true exactly for the given runes.
+func RuneSet(runes ...rune) RunePredicate {
+	ranges := make([]RuneRange, len(runes))
+	for i, r := range runes {
+		ranges[i] = RuneRange{r, r}
+	}
+	return normalizeRanges(ranges)
+}
+
+// Ranges returns the ranges of the predicate, sorted.
+func (p RunePredicate) Ranges() []RuneRange {
+	return append([]RuneRange{}, p.ranges...)
+}
+
+func (p RunePredicate) Contains(r rune) bool {
+	i := sort.Search(len(p.ranges), func(i int) bool { return p.ranges[i].Hi >= r })
+	return i < len(p.ranges) && p.ranges[i].Lo <= r
+}
+
+func (p RunePredicate) IsEmpty() bool {
+	return len(p.ranges) == 0
+}
+
+func (p RunePredicate) Equal(q RunePredicate) bool {
+	if len(p.ranges) != len(q.ranges) {
+		return false
+	}
+	for i := range p.ranges {
+		if p.ranges[i] != q.ranges[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p RunePredicate) And(q RunePredicate) RunePredicate {
+	result := []RuneRange{}
+	for i, j := 0, 0; i < len(p.ranges) && j < len(q.ranges); {
+		lo := max(p.ranges[i].Lo, q.ranges[j].Lo)
+		hi := min(p.ranges[i].Hi, q.ranges[j].Hi)
+		if lo <= hi {
+			result = append(result, RuneRange{lo, hi})
+		}
+		if p.ranges[i].Hi < q.ranges[j].Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return RunePredicate{ranges: result}
+}
+
+func (p RunePredicate) Or(q RunePredicate) RunePredicate {
+	return normalizeRanges(append(p.Ranges(), q.ranges...))
+}
+
+// Not is the complement of p among all runes.
+func (p RunePredicate) Not() RunePredicate {
+	result := []RuneRange{}
+	next := rune(0)
+	for _, r := range p.ranges {
+		if r.Lo > next {
+			result = append(result, RuneRange{next, r.Lo - 1})
+		}
+		next = r.Hi + 1
+	}
+	if next <= unicode.MaxRune {
+		result = append(result, RuneRange{next, unicode.MaxRune})
+	}
+	return RunePredicate{ranges: result}
+}
+
+// Witness returns the smallest rune satisfying p, or false when p is empty.
+func (p RunePredicate) Witness() (rune, bool) {
+	if p.IsEmpty() {
+		return 0, false
+	}
+	return p.ranges[0].Lo, true
+}
+
+// String writes p back in the syntax of ParsePredicate, as a class or its
+// negation, whichever is shorter.
+func (p RunePredicate) String() string {
+	if p.IsEmpty() {
+		return `[^\x00-\x{10FFFF}]`
+	}
+	if len(p.ranges) == 1 && p.ranges[0].Lo == 0 && p.ranges[0].Hi == unicode.MaxRune {
+		return "."
+	}
+	if len(p.ranges) == 1 && p.ranges[0].Lo == p.ranges[0].Hi && p.ranges[0].Lo > ' ' && p.ranges[0].Lo < unicode.MaxRune {
+		return (&syntax.Regexp{Op: syntax.OpLiteral, Rune: []rune{p.ranges[0].Lo}}).String()
+	}
+
+	class, negated := p.ranges, ""
+	if complement := p.Not(); len(complement.ranges) < len(class) {
+		class, negated = complement.ranges, "^"
+	}
+	pairs := make([]rune, 0, 2*len(class))
+	for _, r := range class {
+		pairs = append(pairs, r.Lo, r.Hi)
+	}
+	inner := (&syntax.Regexp{Op: syntax.OpCharClass, Rune: pairs}).String()
+	// syntax prints a class as "[...]" and may itself pick the negated
+	// form; only rewrite the plain one.
+	if negated != "" && len(inner) > 1 && inner[1] != '^' {
+		return "[^" + inner[1:]
+	}
+	return inner
+}
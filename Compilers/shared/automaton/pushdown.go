@@ -0,0 +1,644 @@
+package automaton
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Epsilon labels transitions that do not consume an input symbol.
+const Epsilon = "ε"
+
+const (
+	AcceptByFinalState = "final_state"
+	AcceptByEmptyStack = "empty_stack"
+)
+
+// DefaultPDADepthLimit bounds the number of moves explored on a single branch
+// when searching the configurations of a PushdownAutomaton.
+const DefaultPDADepthLimit = 1000
+
+type PushdownAutomaton struct {
+	States             []string                                   `json:"states"`
+	Alphabet           []string                                   `json:"alphabet"`
+	StackAlphabet      []string                                   `json:"stackAlphabet"`
+	Transitions        map[string]map[string]map[string][]PDAMove `json:"transitions"` // state -> input|ε -> stack top -> moves
+	InitialState       string                                     `json:"initialState"`
+	InitialStackSymbol string                                     `json:"initialStackSymbol"`
+	FinalStates        []string                                   `json:"finalStates"`
+	AcceptBy           string                                     `json:"acceptBy,omitempty"` // "final_state" (implicit), "empty_stack"
+	Positions          map[string]Position                        `json:"positions,omitempty"`
+}
+
+// PDAMove replaces the stack top with Push; Push[0] becomes the new top.
+type PDAMove struct {
+	To   string   `json:"to"`
+	Push []string `json:"push"`
+}
+
+func (pda *PushdownAutomaton) Validate() error {
+	if len(pda.States) == 0 {
+		return fmt.Errorf("automatul trebuie să aibă cel puțin o stare")
+	}
+
+	if len(pda.Alphabet) == 0 {
+		return fmt.Errorf("automatul trebuie să aibă cel puțin un simbol în alfabet")
+	}
+
+	if contains(pda.Alphabet, Epsilon) {
+		return fmt.Errorf("simbolul '%s' este rezervat pentru tranzițiile fără citire", Epsilon)
+	}
+
+	if len(pda.StackAlphabet) == 0 {
+		return fmt.Errorf("automatul trebuie să aibă cel puțin un simbol în alfabetul stivei")
+	}
+
+	if !contains(pda.StackAlphabet, pda.InitialStackSymbol) {
+		return fmt.Errorf("simbolul inițial al stivei '%s' nu există în alfabetul stivei", pda.InitialStackSymbol)
+	}
+
+	if !contains(pda.States, pda.InitialState) {
+		return fmt.Errorf("starea inițială '%s' nu există în mulțimea stărilor", pda.InitialState)
+	}
+
+	for _, finalState := range pda.FinalStates {
+		if !contains(pda.States, finalState) {
+			return fmt.Errorf("starea finală '%s' nu există în mulțimea stărilor", finalState)
+		}
+	}
+
+	if pda.AcceptBy != "" && pda.AcceptBy != AcceptByFinalState && pda.AcceptBy != AcceptByEmptyStack {
+		return fmt.Errorf("modul de acceptare '%s' este invalid (se așteaptă '%s' sau '%s')",
+			pda.AcceptBy, AcceptByFinalState, AcceptByEmptyStack)
+	}
+
+	for fromState, bySymbol := range pda.Transitions {
+		if !contains(pda.States, fromState) {
+			return fmt.Errorf("starea '%s' din tranziții nu există în mulțimea stărilor", fromState)
+		}
+
+		for symbol, byTop := range bySymbol {
+			if symbol != Epsilon && !contains(pda.Alphabet, symbol) {
+				return fmt.Errorf("simbolul '%s' din tranziții nu există în alfabet", symbol)
+			}
+
+			for top, moves := range byTop {
+				if !contains(pda.StackAlphabet, top) {
+					return fmt.Errorf("simbolul de stivă '%s' din tranziții nu există în alfabetul stivei", top)
+				}
+
+				for _, move := range moves {
+					if !contains(pda.States, move.To) {
+						return fmt.Errorf("starea '%s' din tranziții nu există în mulțimea stărilor", move.To)
+					}
+
+					for _, pushed := range move.Push {
+						if !contains(pda.StackAlphabet, pushed) {
+							return fmt.Errorf("simbolul de stivă '%s' din tranziții nu există în alfabetul stivei", pushed)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (pda *PushdownAutomaton) IsInAlphabet(symbol string) bool {
+	return contains(pda.Alphabet, symbol)
+}
+
+func (pda *PushdownAutomaton) IsFinalState(state string) bool {
+	return contains(pda.FinalStates, state)
+}
+
+func (pda *PushdownAutomaton) acceptsByEmptyStack() bool {
+	return pda.AcceptBy == AcceptByEmptyStack
+}
+
+func (pda *PushdownAutomaton) IsDeterministic() bool {
+	for _, bySymbol := range pda.Transitions {
+		for symbol, byTop := range bySymbol {
+			for top, moves := range byTop {
+				if len(moves) > 1 {
+					return false
+				}
+				if symbol != Epsilon && len(bySymbol[Epsilon][top]) > 0 {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (pda *PushdownAutomaton) TypeString() string {
+	mode := "stare finală"
+	if pda.acceptsByEmptyStack() {
+		mode = "stivă vidă"
+	}
+
+	if pda.IsDeterministic() {
+		return fmt.Sprintf("APD (Automat Push-Down Determinist, acceptare prin %s)", mode)
+	}
+	return fmt.Sprintf("APDN (Automat Push-Down Nedeterminist, acceptare prin %s)", mode)
+}
+
+func (pda *PushdownAutomaton) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("=== Automat Push-Down ===\n\n")
+
+	sb.WriteString(fmt.Sprintf("Tip: %s\n\n", pda.TypeString()))
+
+	sb.WriteString(fmt.Sprintf("Stări: {%s}\n", strings.Join(pda.States, ", ")))
+	sb.WriteString(fmt.Sprintf("Alfabet: {%s}\n", strings.Join(pda.Alphabet, ", ")))
+	sb.WriteString(fmt.Sprintf("Alfabetul stivei: {%s}\n", strings.Join(pda.StackAlphabet, ", ")))
+	sb.WriteString(fmt.Sprintf("Stare inițială: %s\n", pda.InitialState))
+	sb.WriteString(fmt.Sprintf("Simbol inițial stivă: %s\n", pda.InitialStackSymbol))
+	sb.WriteString(fmt.Sprintf("Stări finale: {%s}\n\n", strings.Join(pda.FinalStates, ", ")))
+
+	sb.WriteString("Tranziții:\n")
+	for _, state := range pda.States {
+		for _, symbol := range append(append([]string{}, pda.Alphabet...), Epsilon) {
+			for _, top := range pda.StackAlphabet {
+				for _, move := range pda.Transitions[state][symbol][top] {
+					sb.WriteString(fmt.Sprintf("  %s --%s--> %s\n", state, TransitionLabel(symbol, top, move.Push), move.To))
+				}
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// TransitionLabel formats a push-down transition as "input, top / push".
+func TransitionLabel(symbol, top string, push []string) string {
+	pushed := strings.Join(push, "")
+	if pushed == "" {
+		pushed = Epsilon
+	}
+	return fmt.Sprintf("%s, %s / %s", symbol, top, pushed)
+}
+
+// pdaConfig is one node of the configuration search; stack[0] is the top.
+type pdaConfig struct {
+	state    string
+	position int
+	stack    []string
+	parent   *pdaConfig
+	step     *Step
+	depth    int
+}
+
+func (c *pdaConfig) key() string {
+	return fmt.Sprintf("%s|%d|%s", c.state, c.position, strings.Join(c.stack, "\x00"))
+}
+
+func (c *pdaConfig) steps() []Step {
+	steps := []Step{}
+	for current := c; current.parent != nil; current = current.parent {
+		steps = append([]Step{*current.step}, steps...)
+	}
+	return steps
+}
+
+func (pda *PushdownAutomaton) isAccepting(c *pdaConfig, inputLength int) bool {
+	if c.position != inputLength {
+		return false
+	}
+	if pda.acceptsByEmptyStack() {
+		return len(c.stack) == 0
+	}
+	return pda.IsFinalState(c.state)
+}
+
+func (pda *PushdownAutomaton) Simulate(input string) SimulationResult {
+	return pda.SimulateWithDepthLimit(input, DefaultPDADepthLimit)
+}
+
+// SimulateWithDepthLimit searches the nondeterministic branches breadth-first,
+// so an accepting run is always one with the fewest moves. Branches longer
+// than depthLimit moves are abandoned.
+func (pda *PushdownAutomaton) SimulateWithDepthLimit(input string, depthLimit int) SimulationResult {
+	symbols := []string{}
+	positions := []int{}
+	for i, char := range input {
+		symbol := string(char)
+		if !pda.IsInAlphabet(symbol) {
+			return SimulationResult{
+				Accepted: false,
+				Error: &SimulationError{
+					Type:     "invalid_char",
+					Position: i,
+					States:   []string{pda.InitialState},
+					Symbol:   symbol,
+					Message:  fmt.Sprintf("Caracterul '%s' nu aparține alfabetului", symbol),
+				},
+				Steps:       []Step{},
+				FinalStates: []string{pda.InitialState},
+			}
+		}
+		symbols = append(symbols, symbol)
+		positions = append(positions, i)
+	}
+	positions = append(positions, len(input))
+
+	start := &pdaConfig{state: pda.InitialState, stack: []string{pda.InitialStackSymbol}}
+	queue := []*pdaConfig{start}
+	visited := map[string]bool{start.key(): true}
+	furthest := start
+	truncated := false
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if pda.isAccepting(current, len(symbols)) {
+			return SimulationResult{
+				Accepted:    true,
+				Steps:       current.steps(),
+				FinalStates: []string{current.state},
+			}
+		}
+
+		if current.position > furthest.position {
+			furthest = current
+		}
+
+		if len(current.stack) == 0 {
+			continue
+		}
+
+		if current.depth >= depthLimit {
+			truncated = true
+			continue
+		}
+
+		for _, next := range pda.successors(current, symbols, positions) {
+			key := next.key()
+			if !visited[key] {
+				visited[key] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	result := SimulationResult{
+		Accepted:    false,
+		Steps:       furthest.steps(),
+		FinalStates: []string{furthest.state},
+	}
+
+	switch {
+	case truncated:
+		result.Error = &SimulationError{
+			Type:     "depth_limit",
+			Position: positions[furthest.position],
+			States:   []string{furthest.state},
+			Message:  fmt.Sprintf("Căutarea a fost oprită după %d mutări fără a găsi o ramură acceptoare", depthLimit),
+		}
+	case furthest.position < len(symbols):
+		result.Error = &SimulationError{
+			Type:     "no_transition",
+			Position: positions[furthest.position],
+			States:   []string{furthest.state},
+			Symbol:   symbols[furthest.position],
+			Message: fmt.Sprintf("Nicio ramură nu poate citi simbolul '%s' (stare %s, stivă %s)",
+				symbols[furthest.position], furthest.state, formatStack(furthest.stack)),
+		}
+	default:
+		message := fmt.Sprintf("Nicio ramură nu se termină într-o stare acceptoare (stare %s)", furthest.state)
+		if pda.acceptsByEmptyStack() {
+			message = fmt.Sprintf("Nicio ramură nu golește stiva (stivă %s)", formatStack(furthest.stack))
+		}
+		result.Error = &SimulationError{
+			Type:     "not_final",
+			Position: len(input),
+			States:   []string{furthest.state},
+			Message:  message,
+		}
+	}
+
+	return result
+}
+
+// successors lists the configurations reachable in one move; positions maps
+// symbol indexes to byte offsets in the input, with len(input) appended.
+func (pda *PushdownAutomaton) successors(c *pdaConfig, symbols []string, positions []int) []*pdaConfig {
+	top := c.stack[0]
+	next := []*pdaConfig{}
+
+	apply := func(symbol string, consumed int) {
+		for _, move := range pda.Transitions[c.state][symbol][top] {
+			stack := append(append([]string{}, move.Push...), c.stack[1:]...)
+			next = append(next, &pdaConfig{
+				state:    move.To,
+				position: c.position + consumed,
+				stack:    stack,
+				parent:   c,
+				depth:    c.depth + 1,
+				step: &Step{
+					ActiveStates: []string{move.To},
+					CharIndex:    positions[c.position],
+					Symbol:       symbol,
+					Transitions: []Transition{{
+						From:   c.state,
+						To:     move.To,
+						Symbol: TransitionLabel(symbol, top, move.Push),
+					}},
+					Stack: stack,
+				},
+			})
+		}
+	}
+
+	if c.position < len(symbols) {
+		apply(symbols[c.position], 1)
+	}
+	apply(Epsilon, 0)
+
+	return next
+}
+
+func formatStack(stack []string) string {
+	if len(stack) == 0 {
+		return Epsilon
+	}
+	return strings.Join(stack, "")
+}
+
+func (pda *PushdownAutomaton) AddState(name string) error {
+	if name == "" {
+		return fmt.Errorf("numele stării nu poate fi gol")
+	}
+
+	if contains(pda.States, name) {
+		return fmt.Errorf("starea '%s' există deja", name)
+	}
+
+	pda.States = append(pda.States, name)
+	return nil
+}
+
+func (pda *PushdownAutomaton) RemoveState(name string) error {
+	if !contains(pda.States, name) {
+		return fmt.Errorf("starea '%s' nu există", name)
+	}
+
+	if name == pda.InitialState && len(pda.States) == 1 {
+		return fmt.Errorf("nu se poate șterge singura stare a automatului")
+	}
+
+	newStates := make([]string, 0)
+	for _, state := range pda.States {
+		if state != name {
+			newStates = append(newStates, state)
+		}
+	}
+	pda.States = newStates
+
+	newFinalStates := make([]string, 0)
+	for _, state := range pda.FinalStates {
+		if state != name {
+			newFinalStates = append(newFinalStates, state)
+		}
+	}
+	pda.FinalStates = newFinalStates
+
+	delete(pda.Transitions, name)
+
+	for _, bySymbol := range pda.Transitions {
+		for symbol, byTop := range bySymbol {
+			for top, moves := range byTop {
+				newMoves := make([]PDAMove, 0)
+				for _, move := range moves {
+					if move.To != name {
+						newMoves = append(newMoves, move)
+					}
+				}
+				if len(newMoves) > 0 {
+					byTop[top] = newMoves
+				} else {
+					delete(byTop, top)
+				}
+			}
+			if len(byTop) == 0 {
+				delete(bySymbol, symbol)
+			}
+		}
+	}
+
+	if pda.Positions != nil {
+		delete(pda.Positions, name)
+	}
+
+	if name == pda.InitialState && len(pda.States) > 0 {
+		pda.InitialState = pda.States[0]
+	}
+
+	return nil
+}
+
+func (pda *PushdownAutomaton) RenameState(oldName, newName string) error {
+	if oldName == "" || newName == "" {
+		return fmt.Errorf("numele stării nu poate fi gol")
+	}
+
+	if !contains(pda.States, oldName) {
+		return fmt.Errorf("starea '%s' nu există", oldName)
+	}
+
+	if contains(pda.States, newName) {
+		return fmt.Errorf("starea '%s' există deja", newName)
+	}
+
+	for i, state := range pda.States {
+		if state == oldName {
+			pda.States[i] = newName
+			break
+		}
+	}
+
+	if pda.InitialState == oldName {
+		pda.InitialState = newName
+	}
+
+	for i, state := range pda.FinalStates {
+		if state == oldName {
+			pda.FinalStates[i] = newName
+			break
+		}
+	}
+
+	if transitions, exists := pda.Transitions[oldName]; exists {
+		pda.Transitions[newName] = transitions
+		delete(pda.Transitions, oldName)
+	}
+
+	for _, bySymbol := range pda.Transitions {
+		for _, byTop := range bySymbol {
+			for _, moves := range byTop {
+				for i := range moves {
+					if moves[i].To == oldName {
+						moves[i].To = newName
+					}
+				}
+			}
+		}
+	}
+
+	if pda.Positions != nil {
+		if pos, exists := pda.Positions[oldName]; exists {
+			pda.Positions[newName] = pos
+			delete(pda.Positions, oldName)
+		}
+	}
+
+	return nil
+}
+
+func (pda *PushdownAutomaton) SetInitialState(state string) error {
+	if !contains(pda.States, state) {
+		return fmt.Errorf("starea '%s' nu există", state)
+	}
+
+	pda.InitialState = state
+	return nil
+}
+
+func (pda *PushdownAutomaton) ToggleFinalState(state string) error {
+	if !contains(pda.States, state) {
+		return fmt.Errorf("starea '%s' nu există", state)
+	}
+
+	if contains(pda.FinalStates, state) {
+		newFinalStates := make([]string, 0)
+		for _, s := range pda.FinalStates {
+			if s != state {
+				newFinalStates = append(newFinalStates, s)
+			}
+		}
+		pda.FinalStates = newFinalStates
+	} else {
+		pda.FinalStates = append(pda.FinalStates, state)
+	}
+
+	return nil
+}
+
+func (pda *PushdownAutomaton) SetStatePosition(state string, x, y float64) error {
+	if !contains(pda.States, state) {
+		return fmt.Errorf("starea '%s' nu există", state)
+	}
+
+	if pda.Positions == nil {
+		pda.Positions = make(map[string]Position)
+	}
+
+	pda.Positions[state] = Position{X: x, Y: y}
+	return nil
+}
+
+// AddTransition adds (from, symbol, top) -> (to, push); symbol may be Epsilon.
+func (pda *PushdownAutomaton) AddTransition(from, symbol, top, to string, push []string) error {
+	if !contains(pda.States, from) {
+		return fmt.Errorf("starea '%s' nu există", from)
+	}
+
+	if !contains(pda.States, to) {
+		return fmt.Errorf("starea '%s' nu există", to)
+	}
+
+	if symbol != Epsilon && !contains(pda.Alphabet, symbol) {
+		return fmt.Errorf("simbolul '%s' nu este în alfabet", symbol)
+	}
+
+	if !contains(pda.StackAlphabet, top) {
+		return fmt.Errorf("simbolul de stivă '%s' nu este în alfabetul stivei", top)
+	}
+
+	for _, pushed := range push {
+		if !contains(pda.StackAlphabet, pushed) {
+			return fmt.Errorf("simbolul de stivă '%s' nu este în alfabetul stivei", pushed)
+		}
+	}
+
+	if pda.Transitions == nil {
+		pda.Transitions = make(map[string]map[string]map[string][]PDAMove)
+	}
+
+	if pda.Transitions[from] == nil {
+		pda.Transitions[from] = make(map[string]map[string][]PDAMove)
+	}
+
+	if pda.Transitions[from][symbol] == nil {
+		pda.Transitions[from][symbol] = make(map[string][]PDAMove)
+	}
+
+	for _, existing := range pda.Transitions[from][symbol][top] {
+		if existing.To == to && strings.Join(existing.Push, "\x00") == strings.Join(push, "\x00") {
+			return fmt.Errorf("tranziția există deja")
+		}
+	}
+
+	pda.Transitions[from][symbol][top] = append(pda.Transitions[from][symbol][top], PDAMove{To: to, Push: push})
+	return nil
+}
+
+func (pda *PushdownAutomaton) RemoveTransition(from, symbol, top, to string, push []string) error {
+	moves := pda.Transitions[from][symbol][top]
+	newMoves := make([]PDAMove, 0)
+	found := false
+
+	for _, move := range moves {
+		if move.To == to && strings.Join(move.Push, "\x00") == strings.Join(push, "\x00") {
+			found = true
+		} else {
+			newMoves = append(newMoves, move)
+		}
+	}
+
+	if !found {
+		return fmt.Errorf("tranziția nu există")
+	}
+
+	if len(newMoves) > 0 {
+		pda.Transitions[from][symbol][top] = newMoves
+	} else {
+		delete(pda.Transitions[from][symbol], top)
+	}
+
+	return nil
+}
+
+// GraphView flattens the push-down transitions into a FiniteAutomaton whose
+// symbols are the "input, top / push" labels, so the existing graph editor and
+// step animation can draw it.
+func (pda *PushdownAutomaton) GraphView() *FiniteAutomaton {
+	view := &FiniteAutomaton{
+		States:       append([]string{}, pda.States...),
+		Alphabet:     []string{},
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: pda.InitialState,
+		FinalStates:  append([]string{}, pda.FinalStates...),
+		Positions:    pda.Positions,
+	}
+
+	for _, state := range pda.States {
+		view.Transitions[state] = make(map[string][]string)
+		for symbol, byTop := range pda.Transitions[state] {
+			for top, moves := range byTop {
+				for _, move := range moves {
+					label := TransitionLabel(symbol, top, move.Push)
+					if !contains(view.Alphabet, label) {
+						view.Alphabet = append(view.Alphabet, label)
+					}
+					view.Transitions[state][label] = append(view.Transitions[state][label], move.To)
+				}
+			}
+		}
+	}
+
+	return view
+}
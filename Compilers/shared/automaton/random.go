@@ -0,0 +1,317 @@
+package automaton
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+)
+
+// RandomOptions configures RandomDFA and RandomNFA.
+//
+// Density is the probability that a possible transition exists: one per
+// (state, symbol) pair for a DFA, one per (state, symbol, target) triple for
+// an NFA. Acceptance is the fraction of final states. With Connected every
+// state is reachable from the initial one: a random spanning tree is added
+// before the other transitions are drawn.
+type RandomOptions struct {
+	States     int
+	Alphabet   int // symbols a, b, c, ...
+	Density    float64
+	Acceptance float64
+	Connected  bool
+	Rand       *rand.Rand
+}
+
+func (o RandomOptions) validate() error {
+	if o.States < 1 {
+		return fmt.Errorf("numărul de stări trebuie să fie cel puțin 1")
+	}
+	if o.Alphabet < 1 || o.Alphabet > 26 {
+		return fmt.Errorf("dimensiunea alfabetului trebuie să fie între 1 și 26")
+	}
+	if o.Density < 0 || o.Density > 1 {
+		return fmt.Errorf("densitatea trebuie să fie între 0 și 1")
+	}
+	if o.Acceptance < 0 || o.Acceptance > 1 {
+		return fmt.Errorf("proporția stărilor finale trebuie să fie între 0 și 1")
+	}
+	return nil
+}
+
+func (o RandomOptions) random() *rand.Rand {
+	if o.Rand != nil {
+		return o.Rand
+	}
+	return rand.New(rand.NewSource(1))
+}
+
+// randomSkeleton creates the states, alphabet, initial and final states and,
+// with Connected, the spanning tree. It returns the (state, symbol) pairs the
+// tree used.
+func randomSkeleton(opts RandomOptions, random *rand.Rand) (*FiniteAutomaton, map[[2]int]bool) {
+	fa := &FiniteAutomaton{
+		States:      make([]string, opts.States),
+		Alphabet:    make([]string, opts.Alphabet),
+		Transitions: make(map[string]map[string][]string),
+		FinalStates: []string{},
+	}
+	for i := range fa.States {
+		fa.States[i] = fmt.Sprintf("q%d", i)
+	}
+	for a := range fa.Alphabet {
+		fa.Alphabet[a] = string(rune('a' + a))
+	}
+	fa.InitialState = fa.States[0]
+
+	finals := random.Perm(opts.States)[:int(math.Round(opts.Acceptance*float64(opts.States)))]
+	sort.Ints(finals)
+	for _, i := range finals {
+		fa.FinalStates = append(fa.FinalStates, fa.States[i])
+	}
+
+	used := make(map[[2]int]bool)
+	if opts.Connected {
+		// Every state i > 0 gets an incoming transition from an earlier state
+		// on a pair that is still free; there are i*|Σ| - (i-1) >= 1 of them.
+		for i := 1; i < opts.States; i++ {
+			free := [][2]int{}
+			for p := 0; p < i; p++ {
+				for a := 0; a < opts.Alphabet; a++ {
+					if !used[[2]int{p, a}] {
+						free = append(free, [2]int{p, a})
+					}
+				}
+			}
+			pair := free[random.Intn(len(free))]
+			used[pair] = true
+			addTransitionUnchecked(fa, fa.States[pair[0]], fa.Alphabet[pair[1]], fa.States[i])
+		}
+	}
+
+	return fa, used
+}
+
+// RandomDFA generates a random, possibly partial, DFA; Density 1 gives a
+// complete one.
+func RandomDFA(opts RandomOptions) (*FiniteAutomaton, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	random := opts.random()
+	fa, used := randomSkeleton(opts, random)
+
+	for p := 0; p < opts.States; p++ {
+		for a := 0; a < opts.Alphabet; a++ {
+			if used[[2]int{p, a}] || random.Float64() >= opts.Density {
+				continue
+			}
+			addTransitionUnchecked(fa, fa.States[p], fa.Alphabet[a], fa.States[random.Intn(opts.States)])
+		}
+	}
+
+	return fa, nil
+}
+
+// RandomNFA generates a random NFA: each transition p --a--> q is present
+// with probability Density.
+func RandomNFA(opts RandomOptions) (*FiniteAutomaton, error) {
+	if err := opts.validate(); err != nil {
+		return nil, err
+	}
+
+	random := opts.random()
+	fa, _ := randomSkeleton(opts, random)
+
+	for p := 0; p < opts.States; p++ {
+		for a := 0; a < opts.Alphabet; a++ {
+			for q := 0; q < opts.States; q++ {
+				if random.Float64() >= opts.Density {
+					continue
+				}
+				addTransitionUnchecked(fa, fa.States[p], fa.Alphabet[a], fa.States[q])
+			}
+		}
+	}
+
+	return fa, nil
+}
+
+// WordSampler draws words uniformly at random from the language of a DFA, or
+// from its complement over the same alphabet. Counts are exact (math/big), so
+// long words are sampled without bias.
+type WordSampler struct {
+	fa         *FiniteAutomaton
+	table      [][]int // table[state][symbol], -1 for the implicit sink
+	accepted   [][]*big.Int
+	total      []*big.Int // |Σ|^length
+	complement bool
+	rand       *rand.Rand // used when the caller passes none
+}
+
+// NewWordSampler prepares sampling of words up to maxLength. With complement
+// the sampler draws the words the automaton rejects; missing transitions lead
+// to an implicit rejecting sink.
+func NewWordSampler(fa *FiniteAutomaton, maxLength int, complement bool) (*WordSampler, error) {
+	if !fa.IsDeterministic() {
+		return nil, fmt.Errorf("eșantionarea uniformă necesită un automat determinist")
+	}
+	if maxLength < 0 {
+		return nil, fmt.Errorf("lungimea maximă nu poate fi negativă")
+	}
+
+	index := make(map[string]int)
+	for i, state := range fa.States {
+		index[state] = i
+	}
+
+	s := &WordSampler{fa: fa, table: make([][]int, len(fa.States)), complement: complement}
+	for i, state := range fa.States {
+		s.table[i] = make([]int, len(fa.Alphabet))
+		for a, symbol := range fa.Alphabet {
+			s.table[i][a] = -1
+			if next := fa.Transitions[state][symbol]; len(next) > 0 {
+				s.table[i][a] = index[next[0]]
+			}
+		}
+	}
+
+	// accepted[length][state]: accepted words of that length read from state.
+	s.accepted = make([][]*big.Int, maxLength+1)
+	s.total = make([]*big.Int, maxLength+1)
+	size := big.NewInt(int64(len(fa.Alphabet)))
+	for length := 0; length <= maxLength; length++ {
+		s.accepted[length] = make([]*big.Int, len(fa.States))
+		if length == 0 {
+			s.total[0] = big.NewInt(1)
+		} else {
+			s.total[length] = new(big.Int).Mul(s.total[length-1], size)
+		}
+
+		for i, state := range fa.States {
+			count := new(big.Int)
+			if length == 0 {
+				if fa.IsFinalState(state) {
+					count.SetInt64(1)
+				}
+			} else {
+				for _, next := range s.table[i] {
+					if next >= 0 {
+						count.Add(count, s.accepted[length-1][next])
+					}
+				}
+			}
+			s.accepted[length][i] = count
+		}
+	}
+
+	return s, nil
+}
+
+// count is the number of sampled words of the given length read from state
+// (-1 for the sink).
+func (s *WordSampler) count(state, length int) *big.Int {
+	accepted := new(big.Int)
+	if state >= 0 {
+		accepted = s.accepted[length][state]
+	}
+	if s.complement {
+		return new(big.Int).Sub(s.total[length], accepted)
+	}
+	return accepted
+}
+
+func (s *WordSampler) start() int {
+	return indexOf(s.fa.States, s.fa.InitialState)
+}
+
+// Count returns how many words of the given length the sampler draws from.
+func (s *WordSampler) Count(length int) *big.Int {
+	if length < 0 || length >= len(s.accepted) {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(s.count(s.start(), length))
+}
+
+// random returns the caller's generator or, when it is nil, the sampler's
+// own one, created with seed 1 on first use and kept across calls.
+func (s *WordSampler) random(random *rand.Rand) *rand.Rand {
+	if random != nil {
+		return random
+	}
+	if s.rand == nil {
+		s.rand = rand.New(rand.NewSource(1))
+	}
+	return s.rand
+}
+
+// Sample returns a uniformly random word of exactly the given length, or
+// false when there is none. A nil random uses the sampler's own generator.
+func (s *WordSampler) Sample(length int, random *rand.Rand) (string, bool) {
+	if s.Count(length).Sign() == 0 {
+		return "", false
+	}
+	random = s.random(random)
+
+	state := s.start()
+	word := []int{}
+	for remaining := length; remaining > 0; remaining-- {
+		// Pick a symbol with probability proportional to the number of
+		// words that continue through it.
+		var weights []*big.Int
+		var targets []int
+		total := new(big.Int)
+		for a := range s.fa.Alphabet {
+			next := -1
+			if state >= 0 {
+				next = s.table[state][a]
+			}
+			weight := s.count(next, remaining-1)
+			weights = append(weights, weight)
+			targets = append(targets, next)
+			total.Add(total, weight)
+		}
+
+		pick := new(big.Int).Rand(random, total)
+		for a, weight := range weights {
+			if pick.Cmp(weight) < 0 {
+				word = append(word, a)
+				state = targets[a]
+				break
+			}
+			pick.Sub(pick, weight)
+		}
+	}
+
+	return s.fa.symbolWord(word), true
+}
+
+// SampleUpTo returns a word drawn uniformly among all sampled words of
+// length at most maxLength (the sampler's limit when maxLength is larger).
+// A nil random uses the sampler's own generator.
+func (s *WordSampler) SampleUpTo(maxLength int, random *rand.Rand) (string, bool) {
+	random = s.random(random)
+	if maxLength >= len(s.accepted) {
+		maxLength = len(s.accepted) - 1
+	}
+
+	total := new(big.Int)
+	for length := 0; length <= maxLength; length++ {
+		total.Add(total, s.Count(length))
+	}
+	if total.Sign() == 0 {
+		return "", false
+	}
+
+	pick := new(big.Int).Rand(random, total)
+	for length := 0; length <= maxLength; length++ {
+		count := s.Count(length)
+		if pick.Cmp(count) < 0 {
+			return s.Sample(length, random)
+		}
+		pick.Sub(pick, count)
+	}
+	return "", false
+}
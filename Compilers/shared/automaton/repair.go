@@ -0,0 +1,197 @@
+package automaton
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	EditInsert     = "insert"
+	EditDelete     = "delete"
+	EditSubstitute = "substitute"
+)
+
+// EditOperation is one step of a repair. Position is the byte offset in the
+// original input: where Symbol is inserted, or where Original is deleted or
+// replaced by Symbol.
+type EditOperation struct {
+	Type     string `json:"type"`
+	Position int    `json:"position"`
+	Symbol   string `json:"symbol,omitempty"`
+	Original string `json:"original,omitempty"`
+}
+
+// Repair is an accepted string at minimum edit distance from Input, with the
+// operations that turn Input into Output.
+type Repair struct {
+	Input      string          `json:"input"`
+	Output     string          `json:"output"`
+	Distance   int             `json:"distance"`
+	Operations []EditOperation `json:"operations"`
+}
+
+// Repair finds an accepted string at minimum Levenshtein distance from input
+// (insertions, deletions and substitutions of one symbol, each costing 1). It
+// searches the product of the automaton with the Levenshtein automaton of
+// input: a node is a state together with the number of input symbols read,
+// matching a symbol costs 0 and every edit costs 1, so a 0-1 breadth-first
+// search finds the cheapest path to a final state with the whole input read.
+// Among repairs at the same distance deletions are preferred, then
+// substitutions, then insertions. It works for NFAs as well; the result is
+// false when the language is empty.
+func (fa *FiniteAutomaton) Repair(input string) (*Repair, bool) {
+	if fa.HasEpsilonTransitions() {
+		return fa.RemoveEpsilon().Repair(input)
+	}
+
+	type node struct {
+		state    string
+		position int // input symbols read
+	}
+	type visit struct {
+		distance int
+		parent   node
+		op       *EditOperation
+		output   string
+		done     bool
+	}
+
+	symbols := []string{}
+	offsets := []int{}
+	for i, char := range input {
+		symbols = append(symbols, string(char))
+		offsets = append(offsets, i)
+	}
+	offsets = append(offsets, len(input))
+
+	// The 0-1 BFS deque is kept as two halves: front is a stack of the
+	// nodes reached at no cost, popped first, and back a queue of the rest.
+	start := node{fa.InitialState, 0}
+	visits := map[node]*visit{start: {}}
+	front := []node{}
+	back := []node{start}
+
+	relax := func(from, to node, cost int, op *EditOperation, output string) {
+		distance := visits[from].distance + cost
+		if v, seen := visits[to]; seen && (v.done || v.distance <= distance) {
+			return
+		}
+		visits[to] = &visit{distance: distance, parent: from, op: op, output: output}
+		if cost == 0 {
+			front = append(front, to)
+		} else {
+			back = append(back, to)
+		}
+	}
+
+	var goal *node
+	for len(front) > 0 || len(back) > 0 {
+		var current node
+		if len(front) > 0 {
+			current = front[len(front)-1]
+			front = front[:len(front)-1]
+		} else {
+			current = back[0]
+			back = back[1:]
+		}
+		if visits[current].done {
+			continue
+		}
+		visits[current].done = true
+
+		if current.position == len(symbols) && fa.IsFinalState(current.state) {
+			goal = &current
+			break
+		}
+
+		transitions := fa.Transitions[current.state]
+		if current.position < len(symbols) {
+			read := symbols[current.position]
+			next := node{position: current.position + 1}
+			offset := offsets[current.position]
+
+			for _, target := range transitions[read] {
+				next.state = target
+				relax(current, next, 0, nil, read)
+			}
+			relax(current, node{current.state, current.position + 1}, 1,
+				&EditOperation{Type: EditDelete, Position: offset, Original: read}, "")
+			for _, symbol := range fa.Alphabet {
+				if symbol == read {
+					continue
+				}
+				for _, target := range transitions[symbol] {
+					next.state = target
+					relax(current, next, 1, &EditOperation{Type: EditSubstitute, Position: offset, Symbol: symbol, Original: read}, symbol)
+				}
+			}
+		}
+
+		for _, symbol := range fa.Alphabet {
+			for _, target := range transitions[symbol] {
+				relax(current, node{target, current.position}, 1,
+					&EditOperation{Type: EditInsert, Position: offsets[current.position], Symbol: symbol}, symbol)
+			}
+		}
+	}
+
+	if goal == nil {
+		return nil, false
+	}
+
+	repair := &Repair{Input: input, Distance: visits[*goal].distance, Operations: []EditOperation{}}
+	pieces := []string{}
+	for current := *goal; current != start; current = visits[current].parent {
+		v := visits[current]
+		pieces = append(pieces, v.output)
+		if v.op != nil {
+			repair.Operations = append(repair.Operations, *v.op)
+		}
+	}
+	slices.Reverse(pieces)
+	slices.Reverse(repair.Operations)
+	repair.Output = strings.Join(pieces, "")
+
+	return repair, true
+}
+
+// SimulateWithRepair simulates input and, when it is rejected, attaches the
+// nearest accepted string to the error.
+func (fa *FiniteAutomaton) SimulateWithRepair(input string) SimulationResult {
+	result := fa.Simulate(input)
+	if result.Error != nil {
+		if repair, found := fa.Repair(input); found {
+			result.Error.Suggestion = repair
+		}
+	}
+	return result
+}
+
+// Hint is the "did you mean" line shown next to an error.
+func (r *Repair) Hint() string {
+	return fmt.Sprintf("Ați vrut să scrieți '%s'?", r.Output)
+}
+
+func (op EditOperation) String() string {
+	switch op.Type {
+	case EditInsert:
+		return fmt.Sprintf("inserează '%s' la poziția %d", op.Symbol, op.Position)
+	case EditDelete:
+		return fmt.Sprintf("șterge '%s' de la poziția %d", op.Original, op.Position)
+	case EditSubstitute:
+		return fmt.Sprintf("înlocuiește '%s' cu '%s' la poziția %d", op.Original, op.Symbol, op.Position)
+	}
+	return op.Type
+}
+
+// String prints the hint, the distance and the operations, one per line.
+func (r *Repair) String() string {
+	var sb strings.Builder
+	sb.WriteString(r.Hint() + "\n")
+	sb.WriteString(fmt.Sprintf("Distanța de editare: %d\n", r.Distance))
+	for _, op := range r.Operations {
+		sb.WriteString(fmt.Sprintf("  - %s\n", op))
+	}
+	return sb.String()
+}
@@ -0,0 +1,225 @@
+package automaton
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	labelUnknown = 0
+	labelAccept  = 1
+	labelReject  = -1
+)
+
+// sampleDFA is the working representation used while merging states: a
+// partial DFA over symbol indexes whose states are labelled accept, reject or
+// unknown.
+type sampleDFA struct {
+	next   []map[int]int
+	labels []int
+}
+
+func (d *sampleDFA) clone() *sampleDFA {
+	clone := &sampleDFA{
+		next:   make([]map[int]int, len(d.next)),
+		labels: append([]int{}, d.labels...),
+	}
+	for state, transitions := range d.next {
+		clone.next[state] = make(map[int]int, len(transitions))
+		for symbol, to := range transitions {
+			clone.next[state][symbol] = to
+		}
+	}
+	return clone
+}
+
+func (d *sampleDFA) addState() int {
+	d.next = append(d.next, make(map[int]int))
+	d.labels = append(d.labels, labelUnknown)
+	return len(d.next) - 1
+}
+
+// fold merges the subtree rooted at q into p. It fails when an accepting and
+// a rejecting state would be merged.
+func (d *sampleDFA) fold(p, q int) bool {
+	if d.labels[q] != labelUnknown {
+		if d.labels[p] != labelUnknown && d.labels[p] != d.labels[q] {
+			return false
+		}
+		d.labels[p] = d.labels[q]
+	}
+
+	symbols := make([]int, 0, len(d.next[q]))
+	for symbol := range d.next[q] {
+		symbols = append(symbols, symbol)
+	}
+	sort.Ints(symbols)
+
+	for _, symbol := range symbols {
+		qNext := d.next[q][symbol]
+		if pNext, exists := d.next[p][symbol]; exists {
+			if !d.fold(pNext, qNext) {
+				return false
+			}
+		} else {
+			d.next[p][symbol] = qNext
+		}
+	}
+
+	return true
+}
+
+// RPNI infers a DFA consistent with the samples: it accepts every positive
+// word and rejects every negative one. It builds the prefix tree acceptor of
+// both samples and merges states in length-lexicographic order (red-blue
+// framework), keeping a merge only when no negative word becomes accepted.
+func RPNI(positive, negative []string) (*FiniteAutomaton, error) {
+	symbolSet := make(map[string]bool)
+	for _, words := range [][]string{positive, negative} {
+		for _, word := range words {
+			for _, char := range word {
+				symbolSet[string(char)] = true
+			}
+		}
+	}
+
+	alphabet := getKeys(symbolSet)
+	sort.Strings(alphabet)
+	if len(alphabet) == 0 {
+		return nil, fmt.Errorf("exemplele trebuie să conțină cel puțin un simbol")
+	}
+
+	symbolIndex := make(map[string]int)
+	for i, symbol := range alphabet {
+		symbolIndex[symbol] = i
+	}
+
+	// The prefix tree is built from the samples in length-lexicographic order,
+	// so state numbers follow the order RPNI considers them in.
+	samples := make([]string, 0, len(positive)+len(negative))
+	labels := make(map[string]int)
+	for _, word := range positive {
+		samples = append(samples, word)
+		labels[word] = labelAccept
+	}
+	for _, word := range negative {
+		if labels[word] == labelAccept {
+			return nil, fmt.Errorf("cuvântul '%s' apare atât în exemplele pozitive, cât și în cele negative", word)
+		}
+		samples = append(samples, word)
+		labels[word] = labelReject
+	}
+
+	prefixes := make(map[string]bool)
+	for _, word := range samples {
+		runes := []rune(word)
+		for i := 0; i <= len(runes); i++ {
+			prefixes[string(runes[:i])] = true
+		}
+	}
+	ordered := getKeys(prefixes)
+	sort.Slice(ordered, func(i, j int) bool {
+		li, lj := len([]rune(ordered[i])), len([]rune(ordered[j]))
+		if li != lj {
+			return li < lj
+		}
+		return ordered[i] < ordered[j]
+	})
+
+	dfa := &sampleDFA{}
+	stateOf := make(map[string]int)
+	for _, prefix := range ordered {
+		state := dfa.addState()
+		stateOf[prefix] = state
+		dfa.labels[state] = labels[prefix]
+
+		runes := []rune(prefix)
+		if len(runes) > 0 {
+			parent := stateOf[string(runes[:len(runes)-1])]
+			dfa.next[parent][symbolIndex[string(runes[len(runes)-1])]] = state
+		}
+	}
+
+	red := []int{0}
+	isRed := map[int]bool{0: true}
+
+	for {
+		blue := -1
+		blueParent, blueSymbol := -1, -1
+		for _, r := range red {
+			for symbol, to := range dfa.next[r] {
+				if !isRed[to] && (blue == -1 || to < blue) {
+					blue, blueParent, blueSymbol = to, r, symbol
+				}
+			}
+		}
+		if blue == -1 {
+			break
+		}
+
+		merged := false
+		for _, r := range red {
+			candidate := dfa.clone()
+			candidate.next[blueParent][blueSymbol] = r
+			if candidate.fold(r, blue) {
+				dfa = candidate
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			red = append(red, blue)
+			isRed[blue] = true
+		}
+	}
+
+	return dfa.toFiniteAutomaton(alphabet), nil
+}
+
+// toFiniteAutomaton keeps the states reachable from 0 and names them q0, q1,
+// ... in breadth-first order.
+func (d *sampleDFA) toFiniteAutomaton(alphabet []string) *FiniteAutomaton {
+	names := map[int]string{0: "q0"}
+	queue := []int{0}
+	order := []int{}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		order = append(order, state)
+
+		for symbol := range alphabet {
+			if to, exists := d.next[state][symbol]; exists {
+				if _, named := names[to]; !named {
+					names[to] = fmt.Sprintf("q%d", len(names))
+					queue = append(queue, to)
+				}
+			}
+		}
+	}
+
+	fa := &FiniteAutomaton{
+		States:       []string{},
+		Alphabet:     append([]string{}, alphabet...),
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: "q0",
+		FinalStates:  []string{},
+	}
+
+	for _, state := range order {
+		name := names[state]
+		fa.States = append(fa.States, name)
+		fa.Transitions[name] = make(map[string][]string)
+		if d.labels[state] == labelAccept {
+			fa.FinalStates = append(fa.FinalStates, name)
+		}
+		for symbol, symbolName := range alphabet {
+			if to, exists := d.next[state][symbol]; exists {
+				fa.Transitions[name][symbolName] = []string{names[to]}
+			}
+		}
+	}
+
+	return fa
+}
@@ -5,11 +5,25 @@ import (
 	"strings"
 )
 
+// Simulate runs input through the automaton. ε-transitions are removed first;
+// RemoveEpsilon keeps the state names, so the steps refer to the same states.
 func (fa *FiniteAutomaton) Simulate(input string) SimulationResult {
+	if fa.HasEpsilonTransitions() {
+		return fa.RemoveEpsilon().Simulate(input)
+	}
+	var result SimulationResult
 	if fa.IsDeterministic() {
-		return fa.simulateAFD(input)
+		result = fa.simulateAFD(input)
+	} else {
+		result = fa.simulateAFND(input)
+	}
+	// A rejected input weighs the semiring's zero, which for the tropical
+	// semiring is +∞ and has no JSON encoding, so only accepted inputs get one.
+	if fa.IsWeighted() && result.Accepted {
+		weight := fa.Weight(input)
+		result.Weight = &weight
 	}
-	return fa.simulateAFND(input)
+	return result
 }
 
 func (fa *FiniteAutomaton) simulateAFD(input string) SimulationResult {
@@ -0,0 +1,561 @@
+package automaton
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// SymbolicMove reads any rune satisfying Guard, a predicate in the syntax of
+// ParsePredicate, and moves to To.
+type SymbolicMove struct {
+	Guard string `json:"guard"`
+	To    string `json:"to"`
+}
+
+// SymbolicAutomaton is a finite automaton whose transitions are labelled by
+// rune predicates instead of symbols of a finite alphabet, so it can read
+// "any Unicode letter" with a single transition. It may be nondeterministic.
+// Its JSON form declares "type": "symbolic" (AutomatonSymbolic).
+type SymbolicAutomaton struct {
+	Type         string                    `json:"type"`
+	Version      int                       `json:"version,omitempty"`
+	Name         string                    `json:"name,omitempty"`
+	Description  string                    `json:"description,omitempty"`
+	Tags         []string                  `json:"tags,omitempty"`
+	States       []string                  `json:"states"`
+	Transitions  map[string][]SymbolicMove `json:"transitions"`
+	InitialState string                    `json:"initialState"`
+	FinalStates  []string                  `json:"finalStates"`
+	Tests        []EmbeddedTest            `json:"tests,omitempty"`
+
+	guards map[string]RunePredicate // parsed Guard of every move
+}
+
+func (sa *SymbolicAutomaton) Validate() error {
+	if len(sa.States) == 0 {
+		return fmt.Errorf("automatul trebuie să aibă cel puțin o stare")
+	}
+
+	if !contains(sa.States, sa.InitialState) {
+		return fmt.Errorf("starea inițială '%s' nu există în mulțimea stărilor", sa.InitialState)
+	}
+
+	for _, finalState := range sa.FinalStates {
+		if !contains(sa.States, finalState) {
+			return fmt.Errorf("starea finală '%s' nu există în mulțimea stărilor", finalState)
+		}
+	}
+
+	sa.guards = make(map[string]RunePredicate)
+	for fromState, moves := range sa.Transitions {
+		if !contains(sa.States, fromState) {
+			return fmt.Errorf("starea '%s' din tranziții nu există în mulțimea stărilor", fromState)
+		}
+
+		for _, move := range moves {
+			if !contains(sa.States, move.To) {
+				return fmt.Errorf("starea '%s' din tranziții nu există în mulțimea stărilor", move.To)
+			}
+			if _, parsed := sa.guards[move.Guard]; parsed {
+				continue
+			}
+			predicate, err := ParsePredicate(move.Guard)
+			if err != nil {
+				return err
+			}
+			sa.guards[move.Guard] = predicate
+		}
+	}
+
+	return validateEmbeddedTests(sa.Tests)
+}
+
+// RunEmbeddedTests simulates every embedded test, like
+// FiniteAutomaton.RunEmbeddedTests.
+func (sa *SymbolicAutomaton) RunEmbeddedTests() TestReport {
+	return runEmbeddedTests(sa.Tests, sa.Simulate)
+}
+
+// guard returns the predicate of a move, parsing it if the automaton was built
+// in code rather than loaded.
+func (sa *SymbolicAutomaton) guard(move SymbolicMove) RunePredicate {
+	if predicate, parsed := sa.guards[move.Guard]; parsed {
+		return predicate
+	}
+	predicate, _ := ParsePredicate(move.Guard)
+	if sa.guards == nil {
+		sa.guards = make(map[string]RunePredicate)
+	}
+	sa.guards[move.Guard] = predicate
+	return predicate
+}
+
+// addMove adds from --p--> to, reusing the label of an equal guard from
+// labels so constructed automata keep the names the author wrote.
+func (sa *SymbolicAutomaton) addMove(from string, p RunePredicate, to string, labels map[string]RunePredicate) {
+	label := ""
+	for existing, predicate := range labels {
+		if predicate.Equal(p) && (label == "" || existing < label) {
+			label = existing
+		}
+	}
+	if label == "" {
+		label = p.String()
+	}
+
+	if sa.Transitions == nil {
+		sa.Transitions = make(map[string][]SymbolicMove)
+	}
+	sa.Transitions[from] = append(sa.Transitions[from], SymbolicMove{Guard: label, To: to})
+	if sa.guards == nil {
+		sa.guards = make(map[string]RunePredicate)
+	}
+	sa.guards[label] = p
+}
+
+func (sa *SymbolicAutomaton) IsFinalState(state string) bool {
+	return contains(sa.FinalStates, state)
+}
+
+// next returns the states reached from states by reading r, in the order of
+// States.
+func (sa *SymbolicAutomaton) next(states []string, r rune) []string {
+	reached := make(map[string]bool)
+	for _, state := range states {
+		for _, move := range sa.Transitions[state] {
+			if sa.guard(move).Contains(r) {
+				reached[move.To] = true
+			}
+		}
+	}
+	result := []string{}
+	for _, state := range sa.States {
+		if reached[state] {
+			result = append(result, state)
+		}
+	}
+	return result
+}
+
+// IsDeterministic reports whether the guards leaving every state are
+// pairwise disjoint.
+func (sa *SymbolicAutomaton) IsDeterministic() bool {
+	for _, moves := range sa.Transitions {
+		for i := range moves {
+			for j := i + 1; j < len(moves); j++ {
+				if !sa.guard(moves[i]).And(sa.guard(moves[j])).IsEmpty() {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func (sa *SymbolicAutomaton) Accepts(input string) bool {
+	current := []string{sa.InitialState}
+	for _, r := range input {
+		current = sa.next(current, r)
+		if len(current) == 0 {
+			return false
+		}
+	}
+	for _, state := range current {
+		if sa.IsFinalState(state) {
+			return true
+		}
+	}
+	return false
+}
+
+// LongestPrefix returns the length in bytes of the longest prefix of input
+// the automaton accepts, or -1 when there is none.
+func (sa *SymbolicAutomaton) LongestPrefix(input string) int {
+	longest := -1
+	current := []string{sa.InitialState}
+	for offset := 0; ; {
+		for _, state := range current {
+			if sa.IsFinalState(state) {
+				longest = offset
+				break
+			}
+		}
+		if offset >= len(input) {
+			break
+		}
+		r, size := utf8.DecodeRuneInString(input[offset:])
+		current = sa.next(current, r)
+		if len(current) == 0 {
+			break
+		}
+		offset += size
+	}
+	return longest
+}
+
+// Simulate reports the active states after every rune, like
+// FiniteAutomaton.Simulate; the transitions of a step carry the guard that
+// matched as their symbol.
+func (sa *SymbolicAutomaton) Simulate(input string) SimulationResult {
+	current := []string{sa.InitialState}
+	steps := []Step{}
+
+	for i, r := range input {
+		symbol := string(r)
+		transitions := []Transition{}
+		for _, state := range current {
+			for _, move := range sa.Transitions[state] {
+				if sa.guard(move).Contains(r) {
+					transitions = append(transitions, Transition{From: state, To: move.To, Symbol: move.Guard})
+				}
+			}
+		}
+
+		next := sa.next(current, r)
+		if len(next) == 0 {
+			return SimulationResult{
+				Accepted: false,
+				Error: &SimulationError{
+					Type:     "no_transition",
+					Position: i,
+					States:   current,
+					Symbol:   symbol,
+					Message:  fmt.Sprintf("Nicio tranziție din {%s} nu acceptă caracterul '%s'", strings.Join(current, ", "), symbol),
+				},
+				Steps:       steps,
+				FinalStates: current,
+			}
+		}
+
+		current = next
+		steps = append(steps, Step{ActiveStates: current, CharIndex: i, Symbol: symbol, Transitions: transitions})
+	}
+
+	for _, state := range current {
+		if sa.IsFinalState(state) {
+			return SimulationResult{Accepted: true, Steps: steps, FinalStates: current}
+		}
+	}
+	return SimulationResult{
+		Accepted: false,
+		Error: &SimulationError{
+			Type:     "not_final",
+			Position: len(input),
+			States:   current,
+			Message:  fmt.Sprintf("Nicio stare din {%s} nu este finală", strings.Join(current, ", ")),
+		},
+		Steps:       steps,
+		FinalStates: current,
+	}
+}
+
+// minterms splits the runes accepted by some of the predicates into the
+// coarsest blocks on which every predicate is either true or false.
+func minterms(predicates []RunePredicate) []RunePredicate {
+	domain := RunePredicate{}
+	for _, p := range predicates {
+		domain = domain.Or(p)
+	}
+	if domain.IsEmpty() {
+		return nil
+	}
+
+	blocks := []RunePredicate{domain}
+	for _, p := range predicates {
+		refined := []RunePredicate{}
+		for _, block := range blocks {
+			for _, part := range []RunePredicate{block.And(p), block.And(p.Not())} {
+				if !part.IsEmpty() {
+					refined = append(refined, part)
+				}
+			}
+		}
+		blocks = refined
+	}
+	return blocks
+}
+
+// Determinize builds an equivalent deterministic symbolic automaton by the
+// subset construction over minterms: from a set of states, the runes are
+// split into blocks on which every outgoing guard agrees, and blocks leading
+// to the same set are joined into one guard. States are named like in
+// Determinize, "{q0,q1}".
+func (sa *SymbolicAutomaton) Determinize() *SymbolicAutomaton {
+	result := &SymbolicAutomaton{
+		States:       []string{},
+		Transitions:  make(map[string][]SymbolicMove),
+		InitialState: setName([]string{sa.InitialState}),
+		FinalStates:  []string{},
+	}
+
+	seen := map[string]bool{result.InitialState: true}
+	worklist := [][]string{{sa.InitialState}}
+	for len(worklist) > 0 {
+		current := worklist[0]
+		worklist = worklist[1:]
+		name := setName(current)
+
+		result.States = append(result.States, name)
+		for _, state := range current {
+			if sa.IsFinalState(state) {
+				result.FinalStates = append(result.FinalStates, name)
+				break
+			}
+		}
+
+		predicates := []RunePredicate{}
+		for _, state := range current {
+			for _, move := range sa.Transitions[state] {
+				predicates = append(predicates, sa.guard(move))
+			}
+		}
+
+		targets := []string{}
+		guards := make(map[string]RunePredicate)
+		sets := make(map[string][]string)
+		for _, block := range minterms(predicates) {
+			r, _ := block.Witness()
+			target := sa.next(current, r)
+			targetName := setName(target)
+			if _, exists := guards[targetName]; !exists {
+				targets = append(targets, targetName)
+				sets[targetName] = target
+			}
+			guards[targetName] = guards[targetName].Or(block)
+		}
+
+		for _, targetName := range targets {
+			result.addMove(name, guards[targetName], targetName, sa.guards)
+			if !seen[targetName] {
+				seen[targetName] = true
+				worklist = append(worklist, sets[targetName])
+			}
+		}
+	}
+
+	return result
+}
+
+// Complement accepts the strings of runes sa rejects: the automaton is
+// determinized, completed with a sink state reading the runes no guard
+// covers, and its final states are swapped.
+func (sa *SymbolicAutomaton) Complement() *SymbolicAutomaton {
+	dfa := sa.Determinize()
+
+	used := make(map[string]bool)
+	for _, state := range dfa.States {
+		used[state] = true
+	}
+	sink := freshName("∅", used)
+	sinkUsed := false
+	for _, state := range dfa.States {
+		covered := RunePredicate{}
+		for _, move := range dfa.Transitions[state] {
+			covered = covered.Or(dfa.guard(move))
+		}
+		if rest := covered.Not(); !rest.IsEmpty() {
+			dfa.addMove(state, rest, sink, dfa.guards)
+			sinkUsed = true
+		}
+	}
+	if sinkUsed {
+		dfa.States = append(dfa.States, sink)
+		dfa.addMove(sink, AnyRune(), sink, nil)
+	}
+
+	finals := []string{}
+	for _, state := range dfa.States {
+		if !dfa.IsFinalState(state) {
+			finals = append(finals, state)
+		}
+	}
+	dfa.FinalStates = finals
+	return dfa
+}
+
+// SymbolicIntersection builds the product of a and b: a pair reads the runes
+// satisfying both guards, and pairs whose guards do not overlap get no
+// transition. Only pairs reachable from the initial pair are created.
+func SymbolicIntersection(a, b *SymbolicAutomaton) *SymbolicAutomaton {
+	product := &SymbolicAutomaton{
+		States:       []string{},
+		Transitions:  make(map[string][]SymbolicMove),
+		InitialState: pairName(a.InitialState, b.InitialState),
+		FinalStates:  []string{},
+	}
+	labels := make(map[string]RunePredicate)
+	for label, p := range a.guards {
+		labels[label] = p
+	}
+	for label, p := range b.guards {
+		labels[label] = p
+	}
+
+	type pair struct{ p, q string }
+	start := pair{a.InitialState, b.InitialState}
+	seen := map[pair]bool{start: true}
+	queue := []pair{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		name := pairName(current.p, current.q)
+		product.States = append(product.States, name)
+		if a.IsFinalState(current.p) && b.IsFinalState(current.q) {
+			product.FinalStates = append(product.FinalStates, name)
+		}
+
+		for _, moveA := range a.Transitions[current.p] {
+			for _, moveB := range b.Transitions[current.q] {
+				guard := a.guard(moveA).And(b.guard(moveB))
+				if guard.IsEmpty() {
+					continue
+				}
+				next := pair{moveA.To, moveB.To}
+				product.addMove(name, guard, pairName(next.p, next.q), labels)
+				if !seen[next] {
+					seen[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+
+	return product
+}
+
+// IsEmpty reports whether no string is accepted.
+func (sa *SymbolicAutomaton) IsEmpty() bool {
+	_, found := sa.ShortestString()
+	return !found
+}
+
+// ShortestString returns a shortest accepted string, built from the smallest
+// rune of every guard on the way, or false when none is accepted.
+func (sa *SymbolicAutomaton) ShortestString() (string, bool) {
+	type visit struct {
+		parent string
+		r      rune
+	}
+
+	visited := map[string]visit{sa.InitialState: {}}
+	queue := []string{sa.InitialState}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if sa.IsFinalState(current) {
+			runes := []rune{}
+			for state := current; state != sa.InitialState; state = visited[state].parent {
+				runes = append([]rune{visited[state].r}, runes...)
+			}
+			return string(runes), true
+		}
+
+		for _, move := range sa.Transitions[current] {
+			r, ok := sa.guard(move).Witness()
+			if _, seen := visited[move.To]; ok && !seen {
+				visited[move.To] = visit{parent: current, r: r}
+				queue = append(queue, move.To)
+			}
+		}
+	}
+	return "", false
+}
+
+// SymbolicFromFiniteAutomaton turns every single-rune symbol of fa into a
+// literal guard; symbols of several runes cannot be read by a symbolic
+// automaton.
+func SymbolicFromFiniteAutomaton(fa *FiniteAutomaton) (*SymbolicAutomaton, error) {
+	if fa.HasEpsilonTransitions() {
+		fa = fa.RemoveEpsilon()
+	}
+
+	sa := &SymbolicAutomaton{
+		Name:         fa.Name,
+		Description:  fa.Description,
+		States:       append([]string{}, fa.States...),
+		Transitions:  make(map[string][]SymbolicMove),
+		InitialState: fa.InitialState,
+		FinalStates:  append([]string{}, fa.FinalStates...),
+	}
+	for _, t := range fa.TransitionList() {
+		if utf8.RuneCountInString(t.Symbol) != 1 {
+			return nil, fmt.Errorf("simbolul '%s' nu este un singur caracter", t.Symbol)
+		}
+		r, _ := utf8.DecodeRuneInString(t.Symbol)
+		sa.addMove(t.From, RuneSet(r), t.To, nil)
+	}
+	return sa, nil
+}
+
+func (sa *SymbolicAutomaton) TypeString() string {
+	if sa.IsDeterministic() {
+		return "AFD simbolic (predicate peste caractere)"
+	}
+	return "AFND simbolic (predicate peste caractere)"
+}
+
+func (sa *SymbolicAutomaton) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("=== Automat Simbolic ===\n\n")
+	if sa.Name != "" {
+		sb.WriteString(fmt.Sprintf("Nume: %s\n", sa.Name))
+	}
+	sb.WriteString(fmt.Sprintf("Tip: %s\n\n", sa.TypeString()))
+	sb.WriteString(fmt.Sprintf("Stări: {%s}\n", strings.Join(sa.States, ", ")))
+	sb.WriteString(fmt.Sprintf("Stare inițială: %s\n", sa.InitialState))
+	sb.WriteString(fmt.Sprintf("Stări finale: {%s}\n\n", strings.Join(sa.FinalStates, ", ")))
+
+	sb.WriteString("Tranziții:\n")
+	for _, from := range orderedKeys(sa.States, sa.Transitions) {
+		for _, move := range sa.Transitions[from] {
+			sb.WriteString(fmt.Sprintf("  %s --%s--> %s\n", from, move.Guard, move.To))
+		}
+	}
+	return sb.String()
+}
+
+func ParseSymbolicFromJSON(jsonStr string) (*SymbolicAutomaton, error) {
+	var sa SymbolicAutomaton
+
+	err := json.Unmarshal([]byte(jsonStr), &sa)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la parsarea JSON: %v", err)
+	}
+
+	if sa.Type != AutomatonSymbolic {
+		return nil, fmt.Errorf("automatul simbolic trebuie să declare \"type\": \"%s\"", AutomatonSymbolic)
+	}
+
+	if err := sa.Validate(); err != nil {
+		return nil, fmt.Errorf("automat invalid: %v", err)
+	}
+
+	return &sa, nil
+}
+
+func ParseSymbolicFromFile(filename string) (*SymbolicAutomaton, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("eroare la citirea fișierului: %v", err)
+	}
+
+	return ParseSymbolicFromJSON(string(data))
+}
+
+// ToJSON always writes the "type" discriminator.
+func (sa *SymbolicAutomaton) ToJSON() (string, error) {
+	typed := *sa
+	typed.Type = AutomatonSymbolic
+
+	data, err := json.MarshalIndent(typed, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("eroare la serializarea JSON: %v", err)
+	}
+	return string(data), nil
+}
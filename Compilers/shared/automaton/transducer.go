@@ -0,0 +1,667 @@
+package automaton
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	TransducerMealy = "mealy"
+	TransducerMoore = "moore"
+)
+
+// Transducer is a deterministic finite-state machine that translates an input
+// word into an output word instead of only accepting or rejecting it.
+type Transducer interface {
+	Validate() error
+	Simulate(input string) (string, SimulationResult)
+	ToJSON() (string, error)
+	TypeString() string
+	String() string
+}
+
+// TransducerBase holds the parts shared by Mealy and Moore machines. Unlike
+// FiniteAutomaton, every (state, symbol) pair has at most one target state.
+type TransducerBase struct {
+	Type           string                       `json:"type"` // "mealy", "moore"
+	States         []string                     `json:"states"`
+	Alphabet       []string                     `json:"alphabet"`
+	OutputAlphabet []string                     `json:"outputAlphabet"`
+	Transitions    map[string]map[string]string `json:"transitions"`
+	InitialState   string                       `json:"initialState"`
+	Positions      map[string]Position          `json:"positions,omitempty"`
+}
+
+// MealyMachine produces an output on every transition.
+type MealyMachine struct {
+	TransducerBase
+	Outputs map[string]map[string]string `json:"outputs"`
+}
+
+// MooreMachine produces an output on entering every state, including the
+// initial one.
+type MooreMachine struct {
+	TransducerBase
+	Outputs map[string]string `json:"outputs"`
+}
+
+func (t *TransducerBase) validateBase(kind string) error {
+	if t.Type != kind {
+		return fmt.Errorf("tipul traductorului trebuie să fie '%s', nu '%s'", kind, t.Type)
+	}
+
+	if len(t.States) == 0 {
+		return fmt.Errorf("traductorul trebuie să aibă cel puțin o stare")
+	}
+
+	if len(t.Alphabet) == 0 {
+		return fmt.Errorf("traductorul trebuie să aibă cel puțin un simbol în alfabet")
+	}
+
+	if !contains(t.States, t.InitialState) {
+		return fmt.Errorf("starea inițială '%s' nu există în mulțimea stărilor", t.InitialState)
+	}
+
+	for fromState, transitions := range t.Transitions {
+		if !contains(t.States, fromState) {
+			return fmt.Errorf("starea '%s' din tranziții nu există în mulțimea stărilor", fromState)
+		}
+
+		for symbol, toState := range transitions {
+			if !contains(t.Alphabet, symbol) {
+				return fmt.Errorf("simbolul '%s' din tranziții nu există în alfabet", symbol)
+			}
+
+			if !contains(t.States, toState) {
+				return fmt.Errorf("starea '%s' din tranziții nu există în mulțimea stărilor", toState)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *TransducerBase) validateOutput(output string) error {
+	if output != "" && !contains(t.OutputAlphabet, output) {
+		return fmt.Errorf("ieșirea '%s' nu există în alfabetul de ieșire", output)
+	}
+	return nil
+}
+
+func (m *MealyMachine) Validate() error {
+	if err := m.validateBase(TransducerMealy); err != nil {
+		return err
+	}
+
+	for state, outputs := range m.Outputs {
+		for symbol, output := range outputs {
+			if _, exists := m.Transitions[state][symbol]; !exists {
+				return fmt.Errorf("ieșirea '%s' este definită pentru tranziția inexistentă (%s, %s)", output, state, symbol)
+			}
+			if err := m.validateOutput(output); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *MooreMachine) Validate() error {
+	if err := m.validateBase(TransducerMoore); err != nil {
+		return err
+	}
+
+	for state, output := range m.Outputs {
+		if !contains(m.States, state) {
+			return fmt.Errorf("starea '%s' din ieșiri nu există în mulțimea stărilor", state)
+		}
+		if err := m.validateOutput(output); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *TransducerBase) IsInAlphabet(symbol string) bool {
+	return contains(t.Alphabet, symbol)
+}
+
+// run walks the input deterministically; emit returns the output produced by
+// taking the transition from --symbol--> to.
+func (t *TransducerBase) run(input, initialOutput string, emit func(from, symbol, to string) string) (string, SimulationResult) {
+	currentState := t.InitialState
+	steps := []Step{}
+	var output strings.Builder
+	output.WriteString(initialOutput)
+
+	for i, char := range input {
+		symbol := string(char)
+
+		if !t.IsInAlphabet(symbol) {
+			return output.String(), SimulationResult{
+				Accepted: false,
+				Error: &SimulationError{
+					Type:     "invalid_char",
+					Position: i,
+					States:   []string{currentState},
+					Symbol:   symbol,
+					Message:  fmt.Sprintf("Caracterul '%s' nu aparține alfabetului", symbol),
+				},
+				Steps:       steps,
+				FinalStates: []string{currentState},
+			}
+		}
+
+		nextState, exists := t.Transitions[currentState][symbol]
+		if !exists {
+			return output.String(), SimulationResult{
+				Accepted: false,
+				Error: &SimulationError{
+					Type:     "no_transition",
+					Position: i,
+					States:   []string{currentState},
+					Symbol:   symbol,
+					Message:  fmt.Sprintf("Nu există tranziție din %s cu simbolul '%s'", currentState, symbol),
+				},
+				Steps:       steps,
+				FinalStates: []string{currentState},
+			}
+		}
+
+		produced := emit(currentState, symbol, nextState)
+		output.WriteString(produced)
+		steps = append(steps, Step{
+			ActiveStates: []string{nextState},
+			CharIndex:    i,
+			Symbol:       symbol,
+			Transitions: []Transition{{
+				From:   currentState,
+				To:     nextState,
+				Symbol: symbol,
+			}},
+			Output: produced,
+		})
+		currentState = nextState
+	}
+
+	return output.String(), SimulationResult{
+		Accepted:    true,
+		Steps:       steps,
+		FinalStates: []string{currentState},
+	}
+}
+
+// Simulate translates input and returns the concatenated transition outputs.
+// The result is accepted when the whole input could be read.
+func (m *MealyMachine) Simulate(input string) (string, SimulationResult) {
+	return m.run(input, "", func(from, symbol, to string) string {
+		return m.Outputs[from][symbol]
+	})
+}
+
+// Simulate translates input; the output starts with the initial state's
+// output, followed by the output of every state entered.
+func (m *MooreMachine) Simulate(input string) (string, SimulationResult) {
+	return m.run(input, m.Outputs[m.InitialState], func(from, symbol, to string) string {
+		return m.Outputs[to]
+	})
+}
+
+func (m *MealyMachine) TypeString() string {
+	return "Mașină Mealy (ieșire pe tranziții)"
+}
+
+func (m *MooreMachine) TypeString() string {
+	return "Mașină Moore (ieșire pe stări)"
+}
+
+func (t *TransducerBase) writeHeader(sb *strings.Builder, typeString string) {
+	sb.WriteString("=== Traductor Finit ===\n\n")
+
+	sb.WriteString(fmt.Sprintf("Tip: %s\n\n", typeString))
+
+	sb.WriteString(fmt.Sprintf("Stări: {%s}\n", strings.Join(t.States, ", ")))
+	sb.WriteString(fmt.Sprintf("Alfabet intrare: {%s}\n", strings.Join(t.Alphabet, ", ")))
+	sb.WriteString(fmt.Sprintf("Alfabet ieșire: {%s}\n", strings.Join(t.OutputAlphabet, ", ")))
+	sb.WriteString(fmt.Sprintf("Stare inițială: %s\n\n", t.InitialState))
+}
+
+func (m *MealyMachine) String() string {
+	var sb strings.Builder
+	m.writeHeader(&sb, m.TypeString())
+
+	sb.WriteString("Tranziții:\n")
+	for _, state := range m.States {
+		for _, symbol := range m.Alphabet {
+			if nextState, exists := m.Transitions[state][symbol]; exists {
+				sb.WriteString(fmt.Sprintf("  %s --%s/%s--> %s\n", state, symbol, displayOutput(m.Outputs[state][symbol]), nextState))
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func (m *MooreMachine) String() string {
+	var sb strings.Builder
+	m.writeHeader(&sb, m.TypeString())
+
+	sb.WriteString("Ieșiri:\n")
+	for _, state := range m.States {
+		sb.WriteString(fmt.Sprintf("  %s / %s\n", state, displayOutput(m.Outputs[state])))
+	}
+
+	sb.WriteString("\nTranziții:\n")
+	for _, state := range m.States {
+		for _, symbol := range m.Alphabet {
+			if nextState, exists := m.Transitions[state][symbol]; exists {
+				sb.WriteString(fmt.Sprintf("  %s --%s--> %s\n", state, symbol, nextState))
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+func displayOutput(output string) string {
+	if output == "" {
+		return "ε"
+	}
+	return output
+}
+
+func (t *TransducerBase) AddState(name string) error {
+	if name == "" {
+		return fmt.Errorf("numele stării nu poate fi gol")
+	}
+
+	if contains(t.States, name) {
+		return fmt.Errorf("starea '%s' există deja", name)
+	}
+
+	t.States = append(t.States, name)
+
+	if t.Transitions == nil {
+		t.Transitions = make(map[string]map[string]string)
+	}
+	if t.Transitions[name] == nil {
+		t.Transitions[name] = make(map[string]string)
+	}
+
+	return nil
+}
+
+func (t *TransducerBase) removeState(name string) error {
+	if !contains(t.States, name) {
+		return fmt.Errorf("starea '%s' nu există", name)
+	}
+
+	if name == t.InitialState && len(t.States) == 1 {
+		return fmt.Errorf("nu se poate șterge singura stare a traductorului")
+	}
+
+	newStates := make([]string, 0)
+	for _, state := range t.States {
+		if state != name {
+			newStates = append(newStates, state)
+		}
+	}
+	t.States = newStates
+
+	delete(t.Transitions, name)
+
+	for fromState := range t.Transitions {
+		for symbol, toState := range t.Transitions[fromState] {
+			if toState == name {
+				delete(t.Transitions[fromState], symbol)
+			}
+		}
+	}
+
+	if t.Positions != nil {
+		delete(t.Positions, name)
+	}
+
+	if name == t.InitialState && len(t.States) > 0 {
+		t.InitialState = t.States[0]
+	}
+
+	return nil
+}
+
+func (t *TransducerBase) renameState(oldName, newName string) error {
+	if oldName == "" || newName == "" {
+		return fmt.Errorf("numele stării nu poate fi gol")
+	}
+
+	if !contains(t.States, oldName) {
+		return fmt.Errorf("starea '%s' nu există", oldName)
+	}
+
+	if contains(t.States, newName) {
+		return fmt.Errorf("starea '%s' există deja", newName)
+	}
+
+	for i, state := range t.States {
+		if state == oldName {
+			t.States[i] = newName
+			break
+		}
+	}
+
+	if t.InitialState == oldName {
+		t.InitialState = newName
+	}
+
+	if transitions, exists := t.Transitions[oldName]; exists {
+		t.Transitions[newName] = transitions
+		delete(t.Transitions, oldName)
+	}
+
+	for fromState := range t.Transitions {
+		for symbol, toState := range t.Transitions[fromState] {
+			if toState == oldName {
+				t.Transitions[fromState][symbol] = newName
+			}
+		}
+	}
+
+	if t.Positions != nil {
+		if pos, exists := t.Positions[oldName]; exists {
+			t.Positions[newName] = pos
+			delete(t.Positions, oldName)
+		}
+	}
+
+	return nil
+}
+
+func (t *TransducerBase) SetInitialState(state string) error {
+	if !contains(t.States, state) {
+		return fmt.Errorf("starea '%s' nu există", state)
+	}
+
+	t.InitialState = state
+	return nil
+}
+
+func (t *TransducerBase) SetStatePosition(state string, x, y float64) error {
+	if !contains(t.States, state) {
+		return fmt.Errorf("starea '%s' nu există", state)
+	}
+
+	if t.Positions == nil {
+		t.Positions = make(map[string]Position)
+	}
+
+	t.Positions[state] = Position{X: x, Y: y}
+	return nil
+}
+
+func (t *TransducerBase) addTransition(from, symbol, to string) error {
+	if !contains(t.States, from) {
+		return fmt.Errorf("starea '%s' nu există", from)
+	}
+
+	if !contains(t.States, to) {
+		return fmt.Errorf("starea '%s' nu există", to)
+	}
+
+	if !contains(t.Alphabet, symbol) {
+		return fmt.Errorf("simbolul '%s' nu este în alfabet", symbol)
+	}
+
+	if _, exists := t.Transitions[from][symbol]; exists {
+		return fmt.Errorf("există deja o tranziție din %s cu simbolul '%s'", from, symbol)
+	}
+
+	if t.Transitions == nil {
+		t.Transitions = make(map[string]map[string]string)
+	}
+
+	if t.Transitions[from] == nil {
+		t.Transitions[from] = make(map[string]string)
+	}
+
+	t.Transitions[from][symbol] = to
+	return nil
+}
+
+func (t *TransducerBase) removeTransition(from, symbol string) error {
+	if _, exists := t.Transitions[from][symbol]; !exists {
+		return fmt.Errorf("tranziția nu există")
+	}
+
+	delete(t.Transitions[from], symbol)
+	return nil
+}
+
+func (m *MealyMachine) RemoveState(name string) error {
+	if err := m.removeState(name); err != nil {
+		return err
+	}
+
+	delete(m.Outputs, name)
+	for state, outputs := range m.Outputs {
+		for symbol := range outputs {
+			if _, exists := m.Transitions[state][symbol]; !exists {
+				delete(m.Outputs[state], symbol)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (m *MealyMachine) RenameState(oldName, newName string) error {
+	if err := m.renameState(oldName, newName); err != nil {
+		return err
+	}
+
+	if outputs, exists := m.Outputs[oldName]; exists {
+		m.Outputs[newName] = outputs
+		delete(m.Outputs, oldName)
+	}
+
+	return nil
+}
+
+// AddTransition adds from --symbol/output--> to. An empty output stands for ε.
+func (m *MealyMachine) AddTransition(from, symbol, to, output string) error {
+	if err := m.validateOutput(output); err != nil {
+		return err
+	}
+
+	if err := m.addTransition(from, symbol, to); err != nil {
+		return err
+	}
+
+	if m.Outputs == nil {
+		m.Outputs = make(map[string]map[string]string)
+	}
+
+	if m.Outputs[from] == nil {
+		m.Outputs[from] = make(map[string]string)
+	}
+
+	m.Outputs[from][symbol] = output
+	return nil
+}
+
+func (m *MealyMachine) RemoveTransition(from, symbol string) error {
+	if err := m.removeTransition(from, symbol); err != nil {
+		return err
+	}
+
+	delete(m.Outputs[from], symbol)
+	return nil
+}
+
+func (m *MooreMachine) RemoveState(name string) error {
+	if err := m.removeState(name); err != nil {
+		return err
+	}
+
+	delete(m.Outputs, name)
+	return nil
+}
+
+func (m *MooreMachine) RenameState(oldName, newName string) error {
+	if err := m.renameState(oldName, newName); err != nil {
+		return err
+	}
+
+	if output, exists := m.Outputs[oldName]; exists {
+		m.Outputs[newName] = output
+		delete(m.Outputs, oldName)
+	}
+
+	return nil
+}
+
+func (m *MooreMachine) AddTransition(from, symbol, to string) error {
+	return m.addTransition(from, symbol, to)
+}
+
+func (m *MooreMachine) RemoveTransition(from, symbol string) error {
+	return m.removeTransition(from, symbol)
+}
+
+// SetStateOutput sets the output produced on entering state. An empty output
+// stands for ε.
+func (m *MooreMachine) SetStateOutput(state, output string) error {
+	if !contains(m.States, state) {
+		return fmt.Errorf("starea '%s' nu există", state)
+	}
+
+	if err := m.validateOutput(output); err != nil {
+		return err
+	}
+
+	if m.Outputs == nil {
+		m.Outputs = make(map[string]string)
+	}
+
+	m.Outputs[state] = output
+	return nil
+}
+
+func (t *TransducerBase) cloneBase(kind string) TransducerBase {
+	clone := TransducerBase{
+		Type:           kind,
+		States:         append([]string{}, t.States...),
+		Alphabet:       append([]string{}, t.Alphabet...),
+		OutputAlphabet: append([]string{}, t.OutputAlphabet...),
+		Transitions:    make(map[string]map[string]string),
+		InitialState:   t.InitialState,
+	}
+
+	for state, transitions := range t.Transitions {
+		clone.Transitions[state] = make(map[string]string)
+		for symbol, toState := range transitions {
+			clone.Transitions[state][symbol] = toState
+		}
+	}
+
+	if t.Positions != nil {
+		clone.Positions = make(map[string]Position)
+		for state, pos := range t.Positions {
+			clone.Positions[state] = pos
+		}
+	}
+
+	return clone
+}
+
+// ToMealy converts a Moore machine into a Mealy machine over the same states:
+// every transition outputs what its target state outputs. The initial state's
+// output has no transition to live on, so it is dropped from every translation.
+func (m *MooreMachine) ToMealy() *MealyMachine {
+	mealy := &MealyMachine{
+		TransducerBase: m.cloneBase(TransducerMealy),
+		Outputs:        make(map[string]map[string]string),
+	}
+
+	for state, transitions := range m.Transitions {
+		for symbol, toState := range transitions {
+			if mealy.Outputs[state] == nil {
+				mealy.Outputs[state] = make(map[string]string)
+			}
+			mealy.Outputs[state][symbol] = m.Outputs[toState]
+		}
+	}
+
+	return mealy
+}
+
+// ToMoore converts a Mealy machine into a Moore machine by splitting every
+// state into one copy per output produced on its incoming transitions. A
+// split copy is named "state/output". The initial state gets an ε output so
+// translations stay identical.
+func (m *MealyMachine) ToMoore() *MooreMachine {
+	variants := make(map[string]map[string]bool)
+	for _, state := range m.States {
+		variants[state] = make(map[string]bool)
+	}
+	variants[m.InitialState][""] = true
+
+	for state, transitions := range m.Transitions {
+		for symbol, toState := range transitions {
+			variants[toState][m.Outputs[state][symbol]] = true
+		}
+	}
+
+	sortedVariants := make(map[string][]string)
+	for _, state := range m.States {
+		outputs := getKeys(variants[state])
+		if len(outputs) == 0 {
+			outputs = []string{""}
+		}
+		sort.Strings(outputs)
+		sortedVariants[state] = outputs
+	}
+
+	name := func(state, output string) string {
+		if len(sortedVariants[state]) == 1 {
+			return state
+		}
+		return fmt.Sprintf("%s/%s", state, displayOutput(output))
+	}
+
+	moore := &MooreMachine{
+		TransducerBase: TransducerBase{
+			Type:           TransducerMoore,
+			States:         []string{},
+			Alphabet:       append([]string{}, m.Alphabet...),
+			OutputAlphabet: append([]string{}, m.OutputAlphabet...),
+			Transitions:    make(map[string]map[string]string),
+			InitialState:   name(m.InitialState, ""),
+		},
+		Outputs: make(map[string]string),
+	}
+
+	for _, state := range m.States {
+		for _, output := range sortedVariants[state] {
+			mooreState := name(state, output)
+			moore.States = append(moore.States, mooreState)
+			moore.Outputs[mooreState] = output
+			moore.Transitions[mooreState] = make(map[string]string)
+
+			for symbol, toState := range m.Transitions[state] {
+				moore.Transitions[mooreState][symbol] = name(toState, m.Outputs[state][symbol])
+			}
+
+			if pos, exists := m.Positions[state]; exists && len(sortedVariants[state]) == 1 {
+				if moore.Positions == nil {
+					moore.Positions = make(map[string]Position)
+				}
+				moore.Positions[mooreState] = pos
+			}
+		}
+	}
+
+	return moore
+}
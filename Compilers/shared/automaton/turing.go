@@ -0,0 +1,371 @@
+package automaton
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	MoveLeft  = "L"
+	MoveRight = "R"
+	MoveStay  = "S"
+)
+
+// DefaultTMStepLimit stops machines that do not halt on their own.
+const DefaultTMStepLimit = 10000
+
+// TuringMachine is a deterministic machine with one or more tapes. For a
+// k-tape machine the transition key is the k symbols under the heads joined
+// by ",", and every move writes and moves k times. The input is placed on the
+// first tape; the other tapes start blank.
+type TuringMachine struct {
+	States       []string                     `json:"states"`
+	Alphabet     []string                     `json:"alphabet"`
+	TapeAlphabet []string                     `json:"tapeAlphabet"`
+	Blank        string                       `json:"blank"`
+	Tapes        int                          `json:"tapes,omitempty"` // 1 when omitted
+	Transitions  map[string]map[string]TMMove `json:"transitions"`     // state -> read symbols -> move
+	InitialState string                       `json:"initialState"`
+	FinalStates  []string                     `json:"finalStates"`
+	Positions    map[string]Position          `json:"positions,omitempty"`
+}
+
+type TMMove struct {
+	To    string   `json:"to"`
+	Write []string `json:"write"`
+	Move  []string `json:"move"` // "L", "R", "S" per tape
+}
+
+// TapeSnapshot is the written part of a tape around its head.
+type TapeSnapshot struct {
+	Cells  []string `json:"cells"`
+	Offset int      `json:"offset"` // tape index of Cells[0]
+	Head   int      `json:"head"`   // tape index under the head
+}
+
+func (tm *TuringMachine) TapeCount() int {
+	if tm.Tapes <= 0 {
+		return 1
+	}
+	return tm.Tapes
+}
+
+func (tm *TuringMachine) Validate() error {
+	if len(tm.States) == 0 {
+		return fmt.Errorf("mașina trebuie să aibă cel puțin o stare")
+	}
+
+	if len(tm.Alphabet) == 0 {
+		return fmt.Errorf("mașina trebuie să aibă cel puțin un simbol în alfabet")
+	}
+
+	if !contains(tm.TapeAlphabet, tm.Blank) {
+		return fmt.Errorf("simbolul blanc '%s' nu există în alfabetul benzii", tm.Blank)
+	}
+
+	if contains(tm.Alphabet, tm.Blank) {
+		return fmt.Errorf("simbolul blanc '%s' nu poate face parte din alfabetul de intrare", tm.Blank)
+	}
+
+	for _, symbol := range tm.Alphabet {
+		if !contains(tm.TapeAlphabet, symbol) {
+			return fmt.Errorf("simbolul '%s' din alfabet nu există în alfabetul benzii", symbol)
+		}
+	}
+
+	for _, symbol := range tm.TapeAlphabet {
+		if symbol == "" || strings.Contains(symbol, ",") {
+			return fmt.Errorf("simbolul benzii '%s' este invalid (nu poate fi gol sau conține ',')", symbol)
+		}
+	}
+
+	if !contains(tm.States, tm.InitialState) {
+		return fmt.Errorf("starea inițială '%s' nu există în mulțimea stărilor", tm.InitialState)
+	}
+
+	for _, finalState := range tm.FinalStates {
+		if !contains(tm.States, finalState) {
+			return fmt.Errorf("starea finală '%s' nu există în mulțimea stărilor", finalState)
+		}
+	}
+
+	tapes := tm.TapeCount()
+	for fromState, byRead := range tm.Transitions {
+		if !contains(tm.States, fromState) {
+			return fmt.Errorf("starea '%s' din tranziții nu există în mulțimea stărilor", fromState)
+		}
+
+		for read, move := range byRead {
+			readSymbols := strings.Split(read, ",")
+			if len(readSymbols) != tapes {
+				return fmt.Errorf("tranziția (%s, %s) citește %d simboluri, dar mașina are %d benzi",
+					fromState, read, len(readSymbols), tapes)
+			}
+
+			for _, symbol := range readSymbols {
+				if !contains(tm.TapeAlphabet, symbol) {
+					return fmt.Errorf("simbolul '%s' din tranziții nu există în alfabetul benzii", symbol)
+				}
+			}
+
+			if !contains(tm.States, move.To) {
+				return fmt.Errorf("starea '%s' din tranziții nu există în mulțimea stărilor", move.To)
+			}
+
+			if len(move.Write) != tapes || len(move.Move) != tapes {
+				return fmt.Errorf("tranziția (%s, %s) trebuie să scrie și să mute pe toate cele %d benzi",
+					fromState, read, tapes)
+			}
+
+			for i := 0; i < tapes; i++ {
+				if !contains(tm.TapeAlphabet, move.Write[i]) {
+					return fmt.Errorf("simbolul '%s' din tranziții nu există în alfabetul benzii", move.Write[i])
+				}
+				if move.Move[i] != MoveLeft && move.Move[i] != MoveRight && move.Move[i] != MoveStay {
+					return fmt.Errorf("deplasarea '%s' este invalidă (se așteaptă L, R sau S)", move.Move[i])
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (tm *TuringMachine) IsFinalState(state string) bool {
+	return contains(tm.FinalStates, state)
+}
+
+func (tm *TuringMachine) TypeString() string {
+	if tm.TapeCount() == 1 {
+		return "MT (Mașină Turing cu o bandă)"
+	}
+	return fmt.Sprintf("MT (Mașină Turing cu %d benzi)", tm.TapeCount())
+}
+
+// TransitionLabel formats a move as "read / write, move", with one entry per
+// tape grouped in parentheses on multi-tape machines.
+func (tm *TuringMachine) TransitionLabel(read string, move TMMove) string {
+	if tm.TapeCount() == 1 {
+		return fmt.Sprintf("%s / %s, %s", read, move.Write[0], move.Move[0])
+	}
+	return fmt.Sprintf("(%s) / (%s), (%s)", read, strings.Join(move.Write, ","), strings.Join(move.Move, ","))
+}
+
+func (tm *TuringMachine) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("=== Mașină Turing ===\n\n")
+
+	sb.WriteString(fmt.Sprintf("Tip: %s\n\n", tm.TypeString()))
+
+	sb.WriteString(fmt.Sprintf("Stări: {%s}\n", strings.Join(tm.States, ", ")))
+	sb.WriteString(fmt.Sprintf("Alfabet: {%s}\n", strings.Join(tm.Alphabet, ", ")))
+	sb.WriteString(fmt.Sprintf("Alfabetul benzii: {%s}\n", strings.Join(tm.TapeAlphabet, ", ")))
+	sb.WriteString(fmt.Sprintf("Blanc: %s\n", tm.Blank))
+	sb.WriteString(fmt.Sprintf("Stare inițială: %s\n", tm.InitialState))
+	sb.WriteString(fmt.Sprintf("Stări finale: {%s}\n\n", strings.Join(tm.FinalStates, ", ")))
+
+	sb.WriteString("Tranziții:\n")
+	for _, state := range tm.States {
+		for _, read := range tm.sortedReads(state) {
+			move := tm.Transitions[state][read]
+			sb.WriteString(fmt.Sprintf("  %s --%s--> %s\n", state, tm.TransitionLabel(read, move), move.To))
+		}
+	}
+
+	return sb.String()
+}
+
+func (tm *TuringMachine) sortedReads(state string) []string {
+	reads := make([]string, 0, len(tm.Transitions[state]))
+	for read := range tm.Transitions[state] {
+		reads = append(reads, read)
+	}
+	sort.Strings(reads)
+	return reads
+}
+
+type tmTape struct {
+	cells    map[int]string
+	head     int
+	min, max int
+}
+
+func (t *tmTape) read(blank string) string {
+	if symbol, exists := t.cells[t.head]; exists {
+		return symbol
+	}
+	return blank
+}
+
+func (t *tmTape) write(symbol, blank string) {
+	if symbol == blank {
+		delete(t.cells, t.head)
+	} else {
+		t.cells[t.head] = symbol
+	}
+	t.extend(t.head)
+}
+
+func (t *tmTape) extend(position int) {
+	if position < t.min {
+		t.min = position
+	}
+	if position > t.max {
+		t.max = position
+	}
+}
+
+func (t *tmTape) snapshot(blank string) TapeSnapshot {
+	cells := make([]string, 0, t.max-t.min+1)
+	for i := t.min; i <= t.max; i++ {
+		if symbol, exists := t.cells[i]; exists {
+			cells = append(cells, symbol)
+		} else {
+			cells = append(cells, blank)
+		}
+	}
+	return TapeSnapshot{Cells: cells, Offset: t.min, Head: t.head}
+}
+
+func (tm *TuringMachine) Simulate(input string) SimulationResult {
+	return tm.SimulateWithStepLimit(input, DefaultTMStepLimit)
+}
+
+// SimulateWithStepLimit runs the machine until it halts (no transition
+// applies) or a further move would exceed stepLimit moves, so a machine that
+// halts after exactly stepLimit moves is not reported as looping. Every step
+// records the tapes, which span the visited cells so the head is always
+// inside the window.
+func (tm *TuringMachine) SimulateWithStepLimit(input string, stepLimit int) SimulationResult {
+	tapes := make([]*tmTape, tm.TapeCount())
+	for i := range tapes {
+		tapes[i] = &tmTape{cells: make(map[int]string)}
+	}
+
+	index := 0
+	for i, char := range input {
+		symbol := string(char)
+		if !contains(tm.Alphabet, symbol) {
+			return SimulationResult{
+				Accepted: false,
+				Error: &SimulationError{
+					Type:     "invalid_char",
+					Position: i,
+					States:   []string{tm.InitialState},
+					Symbol:   symbol,
+					Message:  fmt.Sprintf("Caracterul '%s' nu aparține alfabetului", symbol),
+				},
+				Steps:       []Step{},
+				FinalStates: []string{tm.InitialState},
+			}
+		}
+		tapes[0].cells[index] = symbol
+		tapes[0].extend(index)
+		index++
+	}
+
+	currentState := tm.InitialState
+	steps := []Step{}
+
+	for {
+		readSymbols := make([]string, len(tapes))
+		for i, tape := range tapes {
+			readSymbols[i] = tape.read(tm.Blank)
+		}
+		read := strings.Join(readSymbols, ",")
+
+		move, exists := tm.Transitions[currentState][read]
+		if !exists {
+			return tm.halt(currentState, read, tapes[0].head, steps)
+		}
+		if len(steps) >= stepLimit {
+			return SimulationResult{
+				Accepted: false,
+				Error: &SimulationError{
+					Type:     "step_limit",
+					Position: tapes[0].head,
+					States:   []string{currentState},
+					Message:  fmt.Sprintf("Mașina nu s-a oprit după %d pași", stepLimit),
+				},
+				Steps:       steps,
+				FinalStates: []string{currentState},
+			}
+		}
+
+		position := tapes[0].head
+		snapshots := make([]TapeSnapshot, len(tapes))
+		for i, tape := range tapes {
+			tape.write(move.Write[i], tm.Blank)
+			switch move.Move[i] {
+			case MoveLeft:
+				tape.head--
+			case MoveRight:
+				tape.head++
+			}
+			tape.extend(tape.head)
+			snapshots[i] = tape.snapshot(tm.Blank)
+		}
+
+		steps = append(steps, Step{
+			ActiveStates: []string{move.To},
+			CharIndex:    position,
+			Symbol:       read,
+			Transitions: []Transition{{
+				From:   currentState,
+				To:     move.To,
+				Symbol: tm.TransitionLabel(read, move),
+			}},
+			Tape: snapshots,
+		})
+		currentState = move.To
+	}
+}
+
+func (tm *TuringMachine) halt(state, read string, head int, steps []Step) SimulationResult {
+	result := SimulationResult{
+		Accepted:    tm.IsFinalState(state),
+		Steps:       steps,
+		FinalStates: []string{state},
+	}
+
+	if !result.Accepted {
+		result.Error = &SimulationError{
+			Type:     "not_final",
+			Position: head,
+			States:   []string{state},
+			Symbol:   read,
+			Message:  fmt.Sprintf("Mașina s-a oprit în starea %s (citind '%s'), care nu este finală", state, read),
+		}
+	}
+
+	return result
+}
+
+// GraphView flattens the machine into a FiniteAutomaton whose symbols are the
+// transition labels, so the existing graph editor and step animation can draw it.
+func (tm *TuringMachine) GraphView() *FiniteAutomaton {
+	view := &FiniteAutomaton{
+		States:       append([]string{}, tm.States...),
+		Alphabet:     []string{},
+		Transitions:  make(map[string]map[string][]string),
+		InitialState: tm.InitialState,
+		FinalStates:  append([]string{}, tm.FinalStates...),
+		Positions:    tm.Positions,
+	}
+
+	for _, state := range tm.States {
+		view.Transitions[state] = make(map[string][]string)
+		for _, read := range tm.sortedReads(state) {
+			move := tm.Transitions[state][read]
+			label := tm.TransitionLabel(read, move)
+			if !contains(view.Alphabet, label) {
+				view.Alphabet = append(view.Alphabet, label)
+			}
+			view.Transitions[state][label] = append(view.Transitions[state][label], move.To)
+		}
+	}
+
+	return view
+}
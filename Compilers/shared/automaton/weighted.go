@@ -0,0 +1,315 @@
+package automaton
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	SemiringBoolean     = "boolean"
+	SemiringTropical    = "tropical"
+	SemiringProbability = "probability"
+)
+
+// Semiring gives the meaning of transition weights: Times combines the
+// weights along a path and Plus combines paths, so Weight is the best path
+// (tropical: minimum cost) or the total over all paths (probability). Better
+// orders single path weights for MostLikelyPath.
+type Semiring struct {
+	Name   string
+	Zero   float64
+	One    float64
+	Plus   func(a, b float64) float64
+	Times  func(a, b float64) float64
+	Better func(a, b float64) bool
+	Valid  func(w float64) bool
+}
+
+var semirings = map[string]Semiring{
+	SemiringBoolean: {
+		Name:   SemiringBoolean,
+		Zero:   0,
+		One:    1,
+		Plus:   math.Max,
+		Times:  math.Min,
+		Better: func(a, b float64) bool { return a > b },
+		Valid:  func(w float64) bool { return w == 0 || w == 1 },
+	},
+	SemiringTropical: {
+		Name:   SemiringTropical,
+		Zero:   math.Inf(1),
+		One:    0,
+		Plus:   math.Min,
+		Times:  func(a, b float64) float64 { return a + b },
+		Better: func(a, b float64) bool { return a < b },
+		Valid:  func(w float64) bool { return !math.IsNaN(w) && !math.IsInf(w, 0) },
+	},
+	SemiringProbability: {
+		Name:   SemiringProbability,
+		Zero:   0,
+		One:    1,
+		Plus:   func(a, b float64) float64 { return a + b },
+		Times:  func(a, b float64) float64 { return a * b },
+		Better: func(a, b float64) bool { return a > b },
+		Valid:  func(w float64) bool { return w >= 0 && w <= 1 },
+	},
+}
+
+// SemiringByName returns one of the predefined semirings; the empty name is
+// the boolean semiring, under which an automaton without weights behaves as
+// before.
+func SemiringByName(name string) (Semiring, error) {
+	if name == "" {
+		name = SemiringBoolean
+	}
+	s, exists := semirings[name]
+	if !exists {
+		return Semiring{}, fmt.Errorf("semiinelul '%s' nu este cunoscut (boolean, tropical, probability)", name)
+	}
+	return s, nil
+}
+
+// IsWeighted reports whether the automaton declares a semiring or weights.
+func (fa *FiniteAutomaton) IsWeighted() bool {
+	if fa.Semiring != "" || len(fa.FinalWeights) > 0 {
+		return true
+	}
+	for _, bySymbol := range fa.Weights {
+		for _, byTarget := range bySymbol {
+			if len(byTarget) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (fa *FiniteAutomaton) semiring() Semiring {
+	s, err := SemiringByName(fa.Semiring)
+	if err != nil {
+		return semirings[SemiringBoolean]
+	}
+	return s
+}
+
+// TransitionWeight is the weight of from --symbol--> to; a transition without
+// an explicit weight weighs One.
+func (fa *FiniteAutomaton) TransitionWeight(from, symbol, to string) float64 {
+	if w, exists := fa.Weights[from][symbol][to]; exists {
+		return w
+	}
+	return fa.semiring().One
+}
+
+// FinalWeight is the weight of stopping in state: Zero for a non-final state,
+// One for a final state without an explicit weight.
+func (fa *FiniteAutomaton) FinalWeight(state string) float64 {
+	if !fa.IsFinalState(state) {
+		return fa.semiring().Zero
+	}
+	if w, exists := fa.FinalWeights[state]; exists {
+		return w
+	}
+	return fa.semiring().One
+}
+
+func (fa *FiniteAutomaton) SetTransitionWeight(from, symbol, to string, weight float64) error {
+	if !contains(fa.Transitions[from][symbol], to) {
+		return fmt.Errorf("tranziția nu există")
+	}
+	if !fa.semiring().Valid(weight) {
+		return fmt.Errorf("ponderea %s nu este validă în semiinelul %s", FormatWeight(weight), fa.semiring().Name)
+	}
+
+	if fa.Weights == nil {
+		fa.Weights = make(map[string]map[string]map[string]float64)
+	}
+	if fa.Weights[from] == nil {
+		fa.Weights[from] = make(map[string]map[string]float64)
+	}
+	if fa.Weights[from][symbol] == nil {
+		fa.Weights[from][symbol] = make(map[string]float64)
+	}
+	fa.Weights[from][symbol][to] = weight
+	return nil
+}
+
+func (fa *FiniteAutomaton) SetFinalWeight(state string, weight float64) error {
+	if !fa.IsFinalState(state) {
+		return fmt.Errorf("starea '%s' nu este finală", state)
+	}
+	if !fa.semiring().Valid(weight) {
+		return fmt.Errorf("ponderea %s nu este validă în semiinelul %s", FormatWeight(weight), fa.semiring().Name)
+	}
+
+	if fa.FinalWeights == nil {
+		fa.FinalWeights = make(map[string]float64)
+	}
+	fa.FinalWeights[state] = weight
+	return nil
+}
+
+// validateWeights checks that the semiring is known and every weight belongs
+// to an existing transition or final state and is a value of the semiring.
+func (fa *FiniteAutomaton) validateWeights() error {
+	s, err := SemiringByName(fa.Semiring)
+	if err != nil {
+		return err
+	}
+	if fa.IsWeighted() && fa.HasEpsilonTransitions() {
+		return fmt.Errorf("automatele ponderate nu pot avea ε-tranziții")
+	}
+
+	for from, bySymbol := range fa.Weights {
+		for symbol, byTarget := range bySymbol {
+			for to, w := range byTarget {
+				if !contains(fa.Transitions[from][symbol], to) {
+					return fmt.Errorf("ponderea tranziției %s --%s--> %s nu corespunde unei tranziții", from, symbol, to)
+				}
+				if !s.Valid(w) {
+					return fmt.Errorf("ponderea %s a tranziției %s --%s--> %s nu este validă în semiinelul %s",
+						FormatWeight(w), from, symbol, to, s.Name)
+				}
+			}
+		}
+	}
+	for state, w := range fa.FinalWeights {
+		if !fa.IsFinalState(state) {
+			return fmt.Errorf("starea '%s' are pondere finală, dar nu este finală", state)
+		}
+		if !s.Valid(w) {
+			return fmt.Errorf("ponderea finală %s a stării '%s' nu este validă în semiinelul %s", FormatWeight(w), state, s.Name)
+		}
+	}
+	return nil
+}
+
+// Weight combines, with the Plus of the semiring, the weights of all accepting
+// paths for input, each the Times product of its transition weights and the
+// final weight of its last state: the minimum cost in the tropical semiring,
+// the total probability in the probability semiring, 1 or 0 for acceptance in
+// the boolean semiring. It is Zero when input is rejected.
+func (fa *FiniteAutomaton) Weight(input string) float64 {
+	if fa.HasEpsilonTransitions() {
+		return fa.RemoveEpsilon().Weight(input)
+	}
+
+	s := fa.semiring()
+	current := map[string]float64{fa.InitialState: s.One}
+
+	for _, char := range input {
+		symbol := string(char)
+		next := make(map[string]float64)
+		for _, state := range fa.States {
+			w, active := current[state]
+			if !active {
+				continue
+			}
+			for _, to := range fa.Transitions[state][symbol] {
+				path := s.Times(w, fa.TransitionWeight(state, symbol, to))
+				if previous, exists := next[to]; exists {
+					path = s.Plus(previous, path)
+				}
+				next[to] = path
+			}
+		}
+		current = next
+	}
+
+	total := s.Zero
+	for _, state := range fa.States {
+		if w, active := current[state]; active && fa.IsFinalState(state) {
+			total = s.Plus(total, s.Times(w, fa.FinalWeight(state)))
+		}
+	}
+	return total
+}
+
+// WeightedPath is one accepting path: the states visited, starting with the
+// initial state, and its weight including the final weight.
+type WeightedPath struct {
+	States []string `json:"states"`
+	Weight float64  `json:"weight"`
+}
+
+// MostLikelyPath finds the best single accepting path for input by the
+// Viterbi algorithm: the cheapest one in the tropical semiring, the most
+// probable one in the probability semiring. Among equally good paths the one
+// through earlier states is kept. It is false when input is rejected.
+func (fa *FiniteAutomaton) MostLikelyPath(input string) (*WeightedPath, bool) {
+	if fa.HasEpsilonTransitions() {
+		return fa.RemoveEpsilon().MostLikelyPath(input)
+	}
+
+	type cell struct {
+		weight float64
+		parent string
+	}
+
+	s := fa.semiring()
+	layers := []map[string]cell{{fa.InitialState: {weight: s.One}}}
+
+	for _, char := range input {
+		symbol := string(char)
+		current := layers[len(layers)-1]
+		next := make(map[string]cell)
+		for _, state := range fa.States {
+			c, active := current[state]
+			if !active {
+				continue
+			}
+			for _, to := range fa.Transitions[state][symbol] {
+				w := s.Times(c.weight, fa.TransitionWeight(state, symbol, to))
+				if best, exists := next[to]; !exists || s.Better(w, best.weight) {
+					next[to] = cell{weight: w, parent: state}
+				}
+			}
+		}
+		layers = append(layers, next)
+	}
+
+	last := layers[len(layers)-1]
+	end, found := "", false
+	best := s.Zero
+	for _, state := range fa.States {
+		c, active := last[state]
+		if !active || !fa.IsFinalState(state) {
+			continue
+		}
+		w := s.Times(c.weight, fa.FinalWeight(state))
+		if w == s.Zero {
+			continue
+		}
+		if !found || s.Better(w, best) {
+			end, best, found = state, w, true
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	states := make([]string, len(layers))
+	states[len(layers)-1] = end
+	for i := len(layers) - 1; i > 0; i-- {
+		states[i-1] = layers[i][states[i]].parent
+	}
+	return &WeightedPath{States: states, Weight: best}, true
+}
+
+// TransitionLabel is the symbol of t, followed by "/weight" when the
+// transition has an explicit weight.
+func (fa *FiniteAutomaton) TransitionLabel(t Transition) string {
+	if w, exists := fa.Weights[t.From][t.Symbol][t.To]; exists {
+		return t.Symbol + "/" + FormatWeight(w)
+	}
+	return t.Symbol
+}
+
+// FormatWeight prints a weight compactly, with ∞ for the tropical zero.
+func FormatWeight(w float64) string {
+	if math.IsInf(w, 1) {
+		return "∞"
+	}
+	return strconv.FormatFloat(w, 'g', -1, 64)
+}